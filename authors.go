@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Kinds of entries in the author selection list
+const (
+	authorOptionAll    = "all"
+	authorOptionTeam   = "team"
+	authorOptionAuthor = "author"
+)
+
+// AuthorFilter selects whose commits are listed
+type AuthorFilter struct {
+	All     bool     // list every author's commits
+	Authors []string // author names or emails
+	Teams   []string // team names from authors.teams
+}
+
+// authorOption is one entry of the author selection list
+type authorOption struct {
+	Kind    string   // "all", "team" or "author"
+	Name    string   // team or author name
+	Emails  []string // every email seen for the author
+	Members []string // team members
+}
+
+// newAuthorFilter returns the startup author filter: the configured default, or the current user
+func newAuthorFilter(config AuthorsConfig, name, email string) AuthorFilter {
+	filter := AuthorFilter{All: config.AllByDefault}
+	if len(config.Default) > 0 {
+		filter.Authors = append(filter.Authors, config.Default...)
+	} else if name != "" {
+		filter.Authors = []string{mailmapName(name, email)}
+	}
+	return filter
+}
+
+// mailmapName returns the name .mailmap maps an identity to
+func mailmapName(name, email string) string {
+	if email == "" {
+		return name
+	}
+	output, err := exec.Command("git", "check-mailmap", fmt.Sprintf("%s <%s>", name, email)).Output()
+	if err != nil {
+		return name
+	}
+	mapped, _, _ := strings.Cut(strings.TrimSpace(string(output)), " <")
+	if mapped == "" {
+		return name
+	}
+	return mapped
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleFold adds value to values, or removes it if already present (ignoring case)
+func toggleFold(values []string, value string) []string {
+	var kept []string
+	for _, v := range values {
+		if !strings.EqualFold(v, value) {
+			kept = append(kept, v)
+		}
+	}
+	if len(kept) == len(values) {
+		kept = append(kept, value)
+	}
+	return kept
+}
+
+// matches reports whether an author (as resolved through .mailmap) passes the filter.
+// Authors and team members match case-insensitively anywhere in the name or email, like
+// git log --author, so "alice" matches "Alice Smith <alice@corp.com>".
+func (f AuthorFilter) matches(name, email string, teams map[string][]string) bool {
+	if f.All {
+		return true
+	}
+	patterns := append([]string{}, f.Authors...)
+	for _, team := range f.Teams {
+		patterns = append(patterns, teams[team]...)
+	}
+	name, email = strings.ToLower(name), strings.ToLower(email)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" && (strings.Contains(name, pattern) || strings.Contains(email, pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// label describes the filter for the status header
+func (f AuthorFilter) label() string {
+	if f.All {
+		return "All authors"
+	}
+	var parts []string
+	for _, team := range f.Teams {
+		parts = append(parts, "@"+team)
+	}
+	parts = append(parts, f.Authors...)
+	if len(parts) == 0 {
+		return "nobody"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// selects reports whether an author list entry is part of the filter
+func (f AuthorFilter) selects(option authorOption) bool {
+	switch option.Kind {
+	case authorOptionAll:
+		return f.All
+	case authorOptionTeam:
+		return containsFold(f.Teams, option.Name)
+	}
+	if containsFold(f.Authors, option.Name) {
+		return true
+	}
+	for _, email := range option.Emails {
+		if containsFold(f.Authors, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// toggle adds or removes an author list entry from the filter
+func (f AuthorFilter) toggle(option authorOption) AuthorFilter {
+	switch option.Kind {
+	case authorOptionAll:
+		f.All = !f.All
+	case authorOptionTeam:
+		f.Teams = toggleFold(f.Teams, option.Name)
+	default:
+		// Deselecting an author also drops entries matching one of their emails
+		if f.selects(option) {
+			var kept []string
+			for _, author := range f.Authors {
+				if !strings.EqualFold(author, option.Name) && !containsFold(option.Emails, author) {
+					kept = append(kept, author)
+				}
+			}
+			f.Authors = kept
+		} else {
+			f.Authors = append(append([]string{}, f.Authors...), option.Name)
+		}
+	}
+	return f
+}
+
+// label describes an author list entry
+func (o authorOption) label() string {
+	switch o.Kind {
+	case authorOptionAll:
+		return "👥 All authors"
+	case authorOptionTeam:
+		return fmt.Sprintf("👥 @%s (%s)", o.Name, strings.Join(o.Members, ", "))
+	}
+	if len(o.Emails) == 0 {
+		return o.Name
+	}
+	return fmt.Sprintf("%s <%s>", o.Name, strings.Join(o.Emails, ", "))
+}
+
+// listAuthorIdentities returns the authors of the given revisions, merged through .mailmap
+// and grouped by name, most recent first
+func listAuthorIdentities(revisions []string) ([]authorOption, error) {
+	args := append([]string{"log", "--format=%aN%x09%aE"}, revisions...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authors: %v", err)
+	}
+
+	var authors []authorOption
+	index := make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		name, email, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if name == "" {
+			continue
+		}
+		key := strings.ToLower(name)
+		i, ok := index[key]
+		if !ok {
+			i = len(authors)
+			index[key] = i
+			authors = append(authors, authorOption{Kind: authorOptionAuthor, Name: name})
+		}
+		if email != "" && !containsFold(authors[i].Emails, email) {
+			authors[i].Emails = append(authors[i].Emails, email)
+		}
+	}
+	return authors, nil
+}
+
+// authorOptions returns the author selection list: all authors, the configured teams, then
+// the individual authors
+func (cp *CherryPicker) authorOptions(authors []authorOption) []authorOption {
+	options := []authorOption{{Kind: authorOptionAll, Name: "all"}}
+
+	var teams []string
+	for team := range cp.config.Authors.Teams {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+	for _, team := range teams {
+		options = append(options, authorOption{Kind: authorOptionTeam, Name: team, Members: cp.config.Authors.Teams[team]})
+	}
+
+	return append(options, authors...)
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -11,8 +10,8 @@ import (
 
 // BranchSelector handles interactive branch selection at startup
 type BranchSelector struct {
-	branches        []string
-	filteredBranches []string
+	branches        []BranchInfo
+	filteredBranches []BranchInfo
 	cursor          int
 	selected        map[string]string // "source" or "target" -> branch name
 	currentStep     string            // "source" or "target"
@@ -21,6 +20,9 @@ type BranchSelector struct {
 	loading         bool
 	searchMode      bool
 	searchQuery     string
+	gitConfig       GitConfig
+	scope           string // "both", "local" or "remote"
+	keymap          map[string][]keyBinding
 }
 
 type branchSelectedMsg struct {
@@ -29,15 +31,18 @@ type branchSelectedMsg struct {
 }
 
 type branchesLoadedMsg struct {
-	branches []string
+	branches []BranchInfo
 	err      error
 }
 
-func NewBranchSelector() *BranchSelector {
+func NewBranchSelector(gitConfig GitConfig, keymap map[string][]keyBinding) *BranchSelector {
 	return &BranchSelector{
 		selected:    make(map[string]string),
 		currentStep: "source",
 		loading:     true,
+		gitConfig:   gitConfig,
+		scope:       normalizeBranchScope(gitConfig.BranchScope),
+		keymap:      keymap,
 	}
 }
 
@@ -45,8 +50,16 @@ func (bs *BranchSelector) Init() tea.Cmd {
 	return bs.loadBranches
 }
 
+// stepRemote returns the remote whose branches are listed for the current step
+func (bs *BranchSelector) stepRemote() string {
+	if bs.currentStep == "target" {
+		return bs.gitConfig.targetRemote()
+	}
+	return bs.gitConfig.sourceRemote()
+}
+
 func (bs *BranchSelector) loadBranches() tea.Msg {
-	branches, err := getAvailableBranches()
+	branches, err := listBranches(bs.stepRemote(), bs.scope, bs.gitConfig.HiddenBranches)
 	return branchesLoadedMsg{
 		branches: branches,
 		err:      err,
@@ -59,29 +72,35 @@ func (bs *BranchSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		bs.loading = false
 		if msg.err != nil {
 			// Handle error case - for now just show empty branches
-			bs.branches = []string{}
-			bs.filteredBranches = []string{}
+			bs.branches = []BranchInfo{}
+			bs.filteredBranches = []BranchInfo{}
 		} else {
 			bs.branches = msg.branches
 			bs.updateFilteredBranches()
+			if bs.cursor >= len(bs.filteredBranches) {
+				bs.cursor = 0
+			}
 		}
 		return bs, nil
 	case tea.KeyMsg:
 		// Handle search mode input
 		if bs.searchMode {
-			switch msg.String() {
-			case "enter":
+			switch bindingAction(bindingsOf(bs.keymap, keyModeStartSearch), msg) {
+			case "quit":
+				bs.cancelled = true
+				return bs, tea.Quit
+			case "keep":
 				// Exit search mode
 				bs.searchMode = false
 				return bs, nil
-			case "esc":
+			case "close":
 				// Cancel search and clear query
 				bs.searchMode = false
 				bs.searchQuery = ""
 				bs.updateFilteredBranches()
 				bs.cursor = 0
 				return bs, nil
-			case "backspace":
+			case "delete":
 				if len(bs.searchQuery) > 0 {
 					bs.searchQuery = bs.searchQuery[:len(bs.searchQuery)-1]
 					bs.updateFilteredBranches()
@@ -100,34 +119,41 @@ func (bs *BranchSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		
 		// Handle normal navigation
-		switch msg.String() {
-		case "ctrl+c", "q":
+		switch bindingAction(bindingsOf(bs.keymap, keyModeStartBranch), msg) {
+		case "quit":
 			bs.cancelled = true
 			return bs, tea.Quit
-		case "/", "f":
+		case "search":
 			// Enter search mode
 			bs.searchMode = true
 			return bs, nil
-		case "up", "k":
+		case "branch_scope":
+			// Cycle between local, remote and all branches
+			bs.scope = nextBranchScope(bs.scope)
+			bs.loading = true
+			return bs, bs.loadBranches
+		case "up":
 			if bs.cursor > 0 {
 				bs.cursor--
 			}
-		case "down", "j":
+		case "down":
 			maxIndex := len(bs.filteredBranches) - 1
 			if bs.cursor < maxIndex {
 				bs.cursor++
 			}
-		case "enter", " ":
+		case "choose":
 			if bs.cursor < len(bs.filteredBranches) {
 				selectedBranch := bs.filteredBranches[bs.cursor]
-				bs.selected[bs.currentStep] = selectedBranch
+				bs.selected[bs.currentStep] = selectedBranch.Name
 				
 				if bs.currentStep == "source" {
 					bs.currentStep = "target"
 					bs.cursor = 0 // Reset cursor for target selection
 					bs.searchMode = false // Reset search mode
 					bs.searchQuery = ""
-					bs.updateFilteredBranches()
+					// The target may live on a different remote
+					bs.loading = true
+					return bs, bs.loadBranches
 				} else {
 					bs.completed = true
 					return bs, tea.Quit
@@ -146,10 +172,10 @@ func (bs *BranchSelector) updateFilteredBranches() {
 	}
 	
 	query := strings.ToLower(bs.searchQuery)
-	bs.filteredBranches = []string{}
+	bs.filteredBranches = []BranchInfo{}
 	
 	for _, branch := range bs.branches {
-		if strings.Contains(strings.ToLower(branch), query) {
+		if strings.Contains(strings.ToLower(branch.Name), query) {
 			bs.filteredBranches = append(bs.filteredBranches, branch)
 		}
 	}
@@ -190,6 +216,9 @@ func (bs *BranchSelector) View() string {
 	
 	s := titleStyle.Render(title) + "\n"
 	s += instructionStyle.Render(instructions) + "\n"
+	s += lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render(fmt.Sprintf("Showing: %s branches (%s)", bs.scope, bs.stepRemote())) + "\n"
 	
 	// Show search input if in search mode
 	if bs.searchMode {
@@ -224,11 +253,15 @@ func (bs *BranchSelector) View() string {
 	} else {
 		for i, branch := range bs.filteredBranches {
 			cursor := " "
+			label := branch.Name + branchDetails(branch)
+			if bs.currentStep == "target" && matchesBranchPattern(bs.gitConfig.ExcludedBranches, branch.Name) {
+				label += " 🔒 protected"
+			}
 			if bs.cursor == i {
 				cursor = ">"
-				s += selectedStyle.Render(fmt.Sprintf("%s %s", cursor, branch)) + "\n"
+				s += selectedStyle.Render(fmt.Sprintf("%s %s", cursor, label)) + "\n"
 			} else {
-				s += normalStyle.Render(fmt.Sprintf("%s %s", cursor, branch)) + "\n"
+				s += normalStyle.Render(fmt.Sprintf("%s %s", cursor, label)) + "\n"
 			}
 		}
 	}
@@ -237,54 +270,52 @@ func (bs *BranchSelector) View() string {
 	if bs.searchMode {
 		s += lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241")).
-			Render("Type to search, Enter to exit search, Esc to clear and exit")
+			Render("Controls: " + strings.Join(append([]string{"type=search"}, bindingControlItems(bindingsOf(bs.keymap, keyModeStartSearch))...), ", "))
 	} else {
 		s += lipgloss.NewStyle().
 			Foreground(lipgloss.Color("241")).
-			Render("Use ↑/↓ or j/k to navigate, Enter to select, / to search, q to quit")
+			Render("Controls: " + strings.Join(bindingControlItems(bindingsOf(bs.keymap, keyModeStartBranch)), ", "))
 	}
 	
 	return s
 }
 
-func getAvailableBranches() ([]string, error) {
-	// Get only local branches
-	cmd := exec.Command("git", "branch", "--format=%(refname:short)")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get branches: %v", err)
+// RunBranchSelector runs the interactive branch selection and returns selected branches.
+// A non-empty source (any rev or range) skips the source step; with a target as well,
+// nothing is asked. keymap holds the configured keys, nil for the defaults.
+func RunBranchSelector(gitConfig GitConfig, keymap map[string][]keyBinding, source, target string) (sourceBranch, targetBranch string, err error) {
+	selector := NewBranchSelector(gitConfig, keymap)
+	if source != "" {
+		selector.selected["source"] = source
+		selector.currentStep = "target"
 	}
 	
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	var branches []string
-	
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
+	if source != "" && target != "" {
+		selector.selected["target"] = target
+	} else {
+		p := tea.NewProgram(selector)
+		if _, err := p.Run(); err != nil {
+			return "", "", fmt.Errorf("failed to run branch selector: %v", err)
+		}
+		
+		if selector.cancelled {
+			return "", "", fmt.Errorf("branch selection cancelled")
+		}
+		
+		if !selector.completed {
+			return "", "", fmt.Errorf("branch selection incomplete")
 		}
-		branches = append(branches, line)
-	}
-	
-	return branches, nil
-}
-
-// RunBranchSelector runs the interactive branch selection and returns selected branches
-func RunBranchSelector() (sourceBranch, targetBranch string, err error) {
-	selector := NewBranchSelector()
-	
-	p := tea.NewProgram(selector)
-	if _, err := p.Run(); err != nil {
-		return "", "", fmt.Errorf("failed to run branch selector: %v", err)
 	}
 	
-	if selector.cancelled {
-		return "", "", fmt.Errorf("branch selection cancelled")
+	// A target that only exists on the remote gets a local tracking branch to pick onto
+	target = selector.selected["target"]
+	created, err := trackRemoteBranch(gitConfig.targetRemote(), target)
+	if err != nil {
+		return "", "", err
 	}
-	
-	if !selector.completed {
-		return "", "", fmt.Errorf("branch selection incomplete")
+	if created {
+		fmt.Printf("🌿 Created local branch %s tracking %s/%s\n", target, gitConfig.targetRemote(), target)
 	}
 	
-	return selector.selected["source"], selector.selected["target"], nil
+	return selector.selected["source"], target, nil
 }
\ No newline at end of file
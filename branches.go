@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Branch scopes control which branches the branch selectors list
+const (
+	branchScopeBoth   = "both"
+	branchScopeLocal  = "local"
+	branchScopeRemote = "remote"
+)
+
+// BranchInfo describes a branch offered by the branch selectors
+type BranchInfo struct {
+	Name       string
+	Local      bool      // a local branch exists
+	Remote     bool      // the remote has a branch of this name
+	LastCommit time.Time // date of the newest commit (local if present, else remote)
+	Ahead      int       // local commits missing on the remote
+	Behind     int       // remote commits missing locally
+}
+
+// RemoteOnly reports whether the branch exists only on the remote
+func (b BranchInfo) RemoteOnly() bool {
+	return b.Remote && !b.Local
+}
+
+// normalizeBranchScope returns a known branch scope, defaulting to both
+func normalizeBranchScope(scope string) string {
+	switch scope {
+	case branchScopeLocal, branchScopeRemote:
+		return scope
+	}
+	return branchScopeBoth
+}
+
+// nextBranchScope cycles both → local → remote → both
+func nextBranchScope(scope string) string {
+	switch normalizeBranchScope(scope) {
+	case branchScopeBoth:
+		return branchScopeLocal
+	case branchScopeLocal:
+		return branchScopeRemote
+	}
+	return branchScopeBoth
+}
+
+// listBranches returns the local branches and the branches of a remote, merged by name.
+// Local branches come first, followed by remote-only ones.
+func listBranches(remote, scope string, hidden []string) ([]BranchInfo, error) {
+	refs := []string{"refs/heads"}
+	if hasRemote(remote) {
+		refs = append(refs, "refs/remotes/"+remote)
+	}
+
+	args := append([]string{"for-each-ref", "--format=%(refname)%09%(committerdate:unix)"}, refs...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branches: %v", err)
+	}
+
+	var branches []BranchInfo
+	index := make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		ref := parts[0]
+		var date time.Time
+		if unix, err := strconv.ParseInt(parts[1], 10, 64); err == nil {
+			date = time.Unix(unix, 0)
+		}
+
+		if name := strings.TrimPrefix(ref, "refs/heads/"); name != ref {
+			index[name] = len(branches)
+			branches = append(branches, BranchInfo{Name: name, Local: true, LastCommit: date})
+			continue
+		}
+
+		name := strings.TrimPrefix(ref, "refs/remotes/"+remote+"/")
+		if name == "HEAD" {
+			continue
+		}
+		if i, ok := index[name]; ok {
+			branches[i].Remote = true
+			continue
+		}
+		index[name] = len(branches)
+		branches = append(branches, BranchInfo{Name: name, Remote: true, LastCommit: date})
+	}
+
+	var listed []BranchInfo
+	for _, branch := range branches {
+		if matchesBranchPattern(hidden, branch.Name) {
+			continue
+		}
+		switch normalizeBranchScope(scope) {
+		case branchScopeLocal:
+			if !branch.Local {
+				continue
+			}
+		case branchScopeRemote:
+			if !branch.Remote {
+				continue
+			}
+		}
+		if branch.Local && branch.Remote {
+			branch.Ahead, branch.Behind = aheadBehind(branch.Name, remote+"/"+branch.Name)
+		}
+		listed = append(listed, branch)
+	}
+	return listed, nil
+}
+
+// aheadBehind counts the commits of local missing on upstream and the reverse
+func aheadBehind(local, upstream string) (int, int) {
+	output, err := exec.Command("git", "rev-list", "--left-right", "--count", local+"..."+upstream).Output()
+	if err != nil {
+		return 0, 0
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	ahead, _ := strconv.Atoi(fields[0])
+	behind, _ := strconv.Atoi(fields[1])
+	return ahead, behind
+}
+
+// branchDetails renders the date, ahead/behind counts and remote-only marker of a branch
+func branchDetails(branch BranchInfo) string {
+	var details []string
+	if !branch.LastCommit.IsZero() {
+		details = append(details, branch.LastCommit.Format("2006-01-02"))
+	}
+	if branch.Ahead > 0 || branch.Behind > 0 {
+		details = append(details, fmt.Sprintf("↑%d ↓%d", branch.Ahead, branch.Behind))
+	}
+	if branch.RemoteOnly() {
+		details = append(details, "☁️  remote only")
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(details, "  ")
+}
+
+// trackRemoteBranch creates a local branch tracking remote/branch when only the remote has it.
+// It reports whether a branch was created.
+func trackRemoteBranch(remote, branch string) (bool, error) {
+	if exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch).Run() == nil {
+		return false, nil
+	}
+
+	ref := remoteBranchRef(remote, branch)
+	if ref == "" || exec.Command("git", "rev-parse", "--verify", "--quiet", ref).Run() != nil {
+		return false, fmt.Errorf("branch %s doesn't exist locally or on %s", branch, remote)
+	}
+	if output, err := exec.Command("git", "branch", "--track", branch, ref).CombinedOutput(); err != nil {
+		return false, fmt.Errorf("failed to create tracking branch %s: %v\n%s", branch, err, strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
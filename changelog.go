@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const defaultChangelogHeading = `## {{.Target}} ({{.Date}})`
+
+// changelogSections lists the headings in output order with the types they collect
+var changelogSections = []struct {
+	Title string
+	Types []string
+}{
+	{"Features", []string{"feat"}},
+	{"Bug Fixes", []string{"fix"}},
+	{"Performance Improvements", []string{"perf"}},
+}
+
+// changelogHeadingData is the data passed to the changelog heading template
+type changelogHeadingData struct {
+	Source string
+	Target string
+	Date   string
+}
+
+// buildChangelogSection renders the picked commits as a Markdown changelog section
+func (cp *CherryPicker) buildChangelogSection(commits []Commit) (string, error) {
+	changelogConfig := cp.config.Changelog
+
+	headingTemplate := changelogConfig.Heading
+	if headingTemplate == "" {
+		headingTemplate = defaultChangelogHeading
+	}
+	tmpl, err := template.New("heading").Parse(headingTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid changelog heading template: %v", err)
+	}
+	var heading bytes.Buffer
+	if err := tmpl.Execute(&heading, changelogHeadingData{
+		Source: cp.config.Git.SourceBranch,
+		Target: cp.config.Git.TargetBranch,
+		Date:   time.Now().Format("2006-01-02"),
+	}); err != nil {
+		return "", fmt.Errorf("failed to render changelog heading: %v", err)
+	}
+
+	entries := make(map[string][]string)
+	var breaking, other []string
+	for _, commit := range commits {
+		cc, ok := parseConventionalCommit(commit.Message, commit.Body)
+		line := changelogLine(cc, commit)
+
+		if cc.Breaking {
+			note := cc.BreakingNote
+			if note == "" {
+				note = cc.Subject
+			}
+			breaking = append(breaking, changelogLine(ConventionalCommit{Scope: cc.Scope, Subject: note}, commit))
+		}
+
+		placed := false
+		if ok {
+			for _, section := range changelogSections {
+				for _, t := range section.Types {
+					if cc.Type == t {
+						entries[section.Title] = append(entries[section.Title], line)
+						placed = true
+					}
+				}
+			}
+		}
+		if !placed && changelogConfig.IncludeOther {
+			other = append(other, line)
+		}
+	}
+
+	var s strings.Builder
+	s.WriteString(strings.TrimSpace(heading.String()) + "\n")
+
+	writeSection := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		s.WriteString("\n### " + title + "\n\n")
+		for _, line := range lines {
+			s.WriteString(line + "\n")
+		}
+	}
+
+	writeSection("⚠ BREAKING CHANGES", breaking)
+	for _, section := range changelogSections {
+		writeSection(section.Title, entries[section.Title])
+	}
+	writeSection("Other Changes", other)
+
+	return s.String(), nil
+}
+
+// changelogLine formats a single changelog bullet
+func changelogLine(cc ConventionalCommit, commit Commit) string {
+	sha := commit.SHA
+	if len(sha) > 8 {
+		sha = sha[:8]
+	}
+
+	line := "- "
+	if cc.Scope != "" {
+		line += "**" + cc.Scope + ":** "
+	}
+	line += cc.Subject + " (" + sha + ")"
+	if len(commit.Tickets) > 0 {
+		line += " " + strings.Join(commit.Tickets, ", ")
+	}
+	return line
+}
+
+// insertChangelogSection adds a section below the title of an existing changelog,
+// or at the top if the file has no "# " title
+func insertChangelogSection(existing, section string) string {
+	if strings.TrimSpace(existing) == "" {
+		return "# Changelog\n\n" + section
+	}
+
+	lines := strings.SplitAfter(existing, "\n")
+	if strings.HasPrefix(lines[0], "# ") {
+		rest := strings.TrimLeft(strings.Join(lines[1:], ""), "\n")
+		return lines[0] + "\n" + section + "\n" + rest
+	}
+	return section + "\n" + existing
+}
+
+// updateChangelog drafts a changelog section for the picked commits and either prints it
+// or commits it to the configured CHANGELOG on the target branch
+func (cp *CherryPicker) updateChangelog(shas []string) error {
+	changelogConfig := cp.config.Changelog
+	commits := cp.commitsForSHAs(shas)
+
+	// Commits that weren't loaded in the list lack details; fetch them so footers are parsed
+	ticketPattern, _ := compileTicketPattern(cp.config.Tickets.Pattern)
+	for i, commit := range commits {
+		if commit.Full != "" {
+			continue
+		}
+		subject := getCommitSubject(commit.SHA)
+		if detailed, err := cp.getCommitDetails(commit.SHA, subject, commit.SHA+" "+subject); err == nil {
+			detailed.Tickets = commitTickets(ticketPattern, detailed)
+			commits[i] = detailed
+		}
+	}
+
+	section, err := cp.buildChangelogSection(commits)
+	if err != nil {
+		return err
+	}
+
+	if changelogConfig.Mode != "commit" {
+		fmt.Println("📝 Changelog draft:")
+		fmt.Println()
+		fmt.Println(section)
+		return nil
+	}
+
+	path := changelogConfig.File
+	if path == "" {
+		path = "CHANGELOG.md"
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(insertChangelogSection(string(existing), section)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+
+	fmt.Printf("📝 Committing changelog update to %s...\n", path)
+	if err := exec.Command("git", "add", path).Run(); err != nil {
+		return fmt.Errorf("failed to stage %s: %v", path, err)
+	}
+	message := fmt.Sprintf("docs(changelog): update for %s", cp.config.Git.TargetBranch)
+	if err := exec.Command("git", "commit", "-m", message).Run(); err != nil {
+		return fmt.Errorf("failed to commit %s: %v", path, err)
+	}
+	return nil
+}
+
+// runChangelog prints a changelog draft for a recorded run for the `changelog` command
+func runChangelog(config *Config, session string) error {
+	ledger, err := LoadLedger(config)
+	if err != nil {
+		return err
+	}
+	run, err := ledger.findRun(session)
+	if err != nil {
+		return err
+	}
+
+	var shas []string
+	for _, entry := range run.Entries {
+		if entry.TargetSHA != "" {
+			shas = append(shas, entry.SourceSHA)
+		}
+	}
+
+	config.Git.SourceBranch = run.Source
+	config.Git.TargetBranch = run.Target
+	config.Changelog.Mode = "print"
+	cp := &CherryPicker{config: config, selected: make(map[string]bool)}
+	return cp.updateChangelog(shas)
+}
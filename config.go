@@ -18,6 +18,39 @@ type Config struct {
 
 	// Behavior configuration
 	Behavior BehaviorConfig `yaml:"behavior"`
+
+	// Pull/merge request configuration
+	PullRequest PullRequestConfig `yaml:"pull_request"`
+
+	// Issue-tracker ticket configuration
+	Tickets TicketConfig `yaml:"tickets"`
+
+	// User-defined hook scripts
+	Hooks HooksConfig `yaml:"hooks"`
+
+	// Backport ledger configuration
+	Ledger LedgerConfig `yaml:"ledger"`
+
+	// Shared backport notes configuration
+	Notes NotesConfig `yaml:"notes"`
+
+	// Release report configuration
+	Report ReportConfig `yaml:"report"`
+
+	// Changelog drafting configuration
+	Changelog ChangelogConfig `yaml:"changelog"`
+
+	// Conventional Commits display and policy configuration
+	Conventional ConventionalConfig `yaml:"conventional"`
+
+	// Rules for which commits may be picked to matching target branches
+	Policies []BranchPolicy `yaml:"policies"`
+
+	// Author filter and team configuration
+	Authors AuthorsConfig `yaml:"authors"`
+
+	// Keybinding configuration
+	Keys KeysConfig `yaml:"keys"`
 }
 
 // GitConfig contains git-related configuration
@@ -25,17 +58,41 @@ type GitConfig struct {
 	// Target branch for cherry-picking (default: "clean-staging")
 	TargetBranch string `yaml:"target_branch"`
 
-	// Source branch to compare against (default: "dev")
+	// Source to pick from: a branch, tag, SHA, PR ref like refs/pull/42/head,
+	// or a range like v1.2..v1.3 (default: "dev")
 	SourceBranch string `yaml:"source_branch"`
 
+	// Only list source commits newer/older than this date, e.g. "2 weeks ago" or "2024-05-01"
+	Since string `yaml:"since"`
+	Until string `yaml:"until"`
+
+	// Only list commits touching these paths or globs, e.g. "services/billing/**" (default: all)
+	Paths []string `yaml:"paths"`
+
 	// Remote name (default: "origin")
 	Remote string `yaml:"remote"`
 
+	// Remote the source branch is read from, e.g. "upstream" (default: remote)
+	SourceRemote string `yaml:"source_remote"`
+
+	// Remote the target branch is pulled from and pushed to (default: remote)
+	TargetRemote string `yaml:"target_remote"`
+
+	// Remote backport branches for pull requests are pushed to, e.g. your fork (default: target_remote)
+	PushRemote string `yaml:"push_remote"`
+
 	// Whether to fetch remote before operations (default: true)
 	AutoFetch bool `yaml:"auto_fetch"`
 
-	// Branches to exclude from running the tool on
+	// Glob patterns of protected target branches; picking onto them requires typing
+	// the branch name and auto-push to them is blocked
 	ExcludedBranches []string `yaml:"excluded_branches"`
+
+	// Glob patterns of branches hidden from the branch selectors
+	HiddenBranches []string `yaml:"hidden_branches"`
+
+	// Branches listed by the branch selectors: "both", "local" or "remote" (default: "both")
+	BranchScope string `yaml:"branch_scope"`
 }
 
 // UIConfig contains user interface configuration
@@ -51,6 +108,21 @@ type UIConfig struct {
 
 	// Maximum commit message length to display (default: 80)
 	MaxCommitMessageLength int `yaml:"max_commit_message_length"`
+
+	// Live preview of the current commit: "auto" (right of the list in wide windows,
+	// below it otherwise), "right", "bottom" or "off" (default: "auto")
+	PreviewPane string `yaml:"preview_pane"`
+
+	// Open the diff viewer side by side instead of unified (default: false)
+	DiffSideBySide bool `yaml:"diff_side_by_side"`
+
+	// Collapse files with more changed lines than this in the diff viewer, 0 to never
+	// collapse (default: 400)
+	DiffCollapseLines int `yaml:"diff_collapse_lines"`
+
+	// Extra file patterns collapsed as generated in the diff viewer, like "*.pb.go" or
+	// "gen/**"; lock files, minified and vendored files are always collapsed
+	GeneratedFiles []string `yaml:"generated_files"`
 }
 
 // BehaviorConfig contains behavior-related configuration
@@ -64,8 +136,185 @@ type BehaviorConfig struct {
 	// Auto-push after successful cherry-pick (default: false)
 	AutoPush bool `yaml:"auto_push"`
 
+	// Show the commits about to be pushed and ask before auto-pushing (default: true)
+	PreviewPush bool `yaml:"preview_push"`
+
 	// Exit after successful cherry-pick (default: true)
 	ExitAfterAction bool `yaml:"exit_after_action"`
+
+	// Command run on the target to verify picks, e.g. "go build ./..." (default: none)
+	VerifyCommand string `yaml:"verify_command"`
+
+	// When to run the verify command: "each" pick or once at the "end" (default: "each")
+	VerifyMode string `yaml:"verify_mode"`
+}
+
+// PullRequestConfig contains settings for opening pull/merge requests after a run
+type PullRequestConfig struct {
+	// Pick onto a backport branch, push it and open a pull/merge request instead of
+	// pushing the target (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// Forge type: "github", "gitlab" or "gitea" (default: "github")
+	Forge string `yaml:"forge"`
+
+	// API base URL, e.g. "https://api.github.com" (default: public host of the forge)
+	BaseURL string `yaml:"base_url"`
+
+	// Repository path such as "owner/repo" (default: derived from the remote URL)
+	Repository string `yaml:"repository"`
+
+	// Environment variable holding the API token (default: GITHUB_TOKEN, GITLAB_TOKEN or GITEA_TOKEN)
+	TokenEnv string `yaml:"token_env"`
+
+	// Prefix for backport branches, pushed as <prefix>/<target>/<topic> (default: "backport")
+	BranchPrefix string `yaml:"branch_prefix"`
+
+	// Go text/template for the request title
+	TitleTemplate string `yaml:"title_template"`
+
+	// Go text/template for the request body
+	BodyTemplate string `yaml:"body_template"`
+}
+
+// TicketConfig contains issue-tracker ticket extraction settings
+type TicketConfig struct {
+	// Regular expression matching ticket keys in commit subjects and bodies, e.g.
+	// "\\b(ABC|OPS)-[0-9]+\\b" (default: "", no ticket extraction)
+	Pattern string `yaml:"pattern"`
+}
+
+// HooksConfig contains shell commands run at points of the cherry-pick run
+type HooksConfig struct {
+	// Commands run once before switching to the target branch
+	PreRun []string `yaml:"pre_run"`
+
+	// Commands run before each commit is picked
+	PreCommitPick []string `yaml:"pre_commit_pick"`
+
+	// Commands run after each commit is picked successfully
+	PostCommitPick []string `yaml:"post_commit_pick"`
+
+	// Commands run when a pick stops on a conflict
+	OnConflict []string `yaml:"on_conflict"`
+
+	// Commands run once after all commits were picked
+	PostRun []string `yaml:"post_run"`
+
+	// What a failing pre_commit_pick hook does: "skip" the commit or "abort" the run (default: "abort")
+	OnFailure string `yaml:"on_failure"`
+}
+
+// LedgerConfig contains settings for the persistent backport ledger
+type LedgerConfig struct {
+	// Record every run in the ledger (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// Ledger file location (default: .git/cherry-picker/ledger.json)
+	Path string `yaml:"path"`
+}
+
+// NotesConfig contains settings for backport metadata stored in git notes
+type NotesConfig struct {
+	// Write a note on each source commit recording where it was picked (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// Notes ref name, stored under refs/notes/ (default: "cherry-picker")
+	Ref string `yaml:"ref"`
+
+	// Fetch the notes ref with the remote and push it after pushing picks (default: false)
+	Sync bool `yaml:"sync"`
+}
+
+// ReportConfig contains settings for release reports
+type ReportConfig struct {
+	// Write a report after each successful run (default: false)
+	OnRun bool `yaml:"on_run"`
+
+	// Report format: "markdown", "html" or "csv" (default: "markdown")
+	Format string `yaml:"format"`
+
+	// Report file written after a run (default: .git/cherry-picker/reports/report-<session>.<ext>)
+	Output string `yaml:"output"`
+
+	// Directory with report.md.tmpl, report.html.tmpl or report.csv.tmpl overriding the built-in templates
+	TemplateDir string `yaml:"template_dir"`
+}
+
+// ChangelogConfig contains settings for drafting changelog sections from picked commits
+type ChangelogConfig struct {
+	// Draft a changelog section after each successful run (default: false)
+	Enabled bool `yaml:"enabled"`
+
+	// "print" the section for review or "commit" it to the changelog file (default: "print")
+	Mode string `yaml:"mode"`
+
+	// Changelog file on the target branch (default: "CHANGELOG.md")
+	File string `yaml:"file"`
+
+	// Go text/template for the section heading; fields: .Source .Target .Date
+	Heading string `yaml:"heading"`
+
+	// List non-conventional and other commit types under "Other Changes" (default: false)
+	IncludeOther bool `yaml:"include_other"`
+}
+
+// ConventionalConfig contains settings for Conventional Commits types
+type ConventionalConfig struct {
+	// Show colored type badges in the commit list (default: true)
+	Badges bool `yaml:"badges"`
+
+	// Commit types shown at startup, e.g. ["fix", "perf"]; "other" matches non-conventional commits (default: all)
+	Types []string `yaml:"types"`
+
+	// Glob patterns of hotfix target branches (default: ["hotfix/*", "hotfix-*"])
+	HotfixTargets []string `yaml:"hotfix_targets"`
+
+	// Warn when feat commits are selected for a hotfix target (default: true)
+	WarnFeatOnHotfix bool `yaml:"warn_feat_on_hotfix"`
+}
+
+// AuthorsConfig controls whose commits are listed
+type AuthorsConfig struct {
+	// List every author's commits at startup instead of only yours (default: false)
+	AllByDefault bool `yaml:"all_by_default"`
+
+	// Names or emails listed at startup instead of you
+	Default []string `yaml:"default"`
+
+	// Named teams of author names or emails, selectable in the author list
+	Teams map[string][]string `yaml:"teams"`
+}
+
+// KeysConfig binds keys to the named actions of each TUI mode
+type KeysConfig struct {
+	// Bindings to start from: "default", "vim" or "emacs" (default: "default")
+	Preset string `yaml:"preset"`
+
+	// Keys by mode and action, replacing the preset's keys for the action, e.g.
+	// list: {execute: ["X"], down: ["j", "ctrl+n"]}. An empty list unbinds the action.
+	Bindings map[string]map[string][]string `yaml:"bindings"`
+}
+
+// BranchPolicy restricts which commits may be picked to target branches matching a pattern
+type BranchPolicy struct {
+	// Glob pattern of target branches the policy applies to, e.g. "release/*"
+	Target string `yaml:"target"`
+
+	// Regular expression the commit message must match (default: any message)
+	AllowPattern string `yaml:"allow_pattern"`
+
+	// Forbid merge commits (default: false)
+	NoMerges bool `yaml:"no_merges"`
+
+	// Maximum inserted plus deleted lines per commit, 0 for no limit (default: 0)
+	MaxDiffLines int `yaml:"max_diff_lines"`
+
+	// Require a ticket reference in the message (default: false)
+	RequireTicket bool `yaml:"require_ticket"`
+
+	// Paths that may not be touched; entries ending in "/" match directories, others are globs
+	BlockedPaths []string `yaml:"blocked_paths"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -77,18 +326,58 @@ func DefaultConfig() *Config {
 			Remote:           "origin",
 			AutoFetch:        true,
 			ExcludedBranches: []string{"dev", "staging", "live", "main", "master"},
+			BranchScope:      branchScopeBoth,
 		},
 		UI: UIConfig{
 			CursorBlinkInterval:    500,
 			ShowCommitDate:         false,
 			ShowCommitAuthor:       false,
 			MaxCommitMessageLength: 80,
+			PreviewPane:            "auto",
+			DiffSideBySide:         false,
+			DiffCollapseLines:      400,
 		},
 		Behavior: BehaviorConfig{
 			DefaultReverse:      false,
 			ConfirmBeforeAction: true,
 			AutoPush:            false,
+			PreviewPush:         true,
 			ExitAfterAction:     true,
+			VerifyMode:          "each",
+		},
+		PullRequest: PullRequestConfig{
+			Enabled:       false,
+			Forge:         "github",
+			BranchPrefix:  "backport",
+			TitleTemplate: defaultPullRequestTitleTemplate,
+			BodyTemplate:  defaultPullRequestBodyTemplate,
+		},
+		Hooks: HooksConfig{
+			OnFailure: "abort",
+		},
+		Ledger: LedgerConfig{
+			Enabled: true,
+		},
+		Notes: NotesConfig{
+			Enabled: false,
+			Ref:     "cherry-picker",
+			Sync:    false,
+		},
+		Report: ReportConfig{
+			Format: "markdown",
+		},
+		Changelog: ChangelogConfig{
+			Mode:    "print",
+			File:    "CHANGELOG.md",
+			Heading: defaultChangelogHeading,
+		},
+		Conventional: ConventionalConfig{
+			Badges:           true,
+			HotfixTargets:    []string{"hotfix/*", "hotfix-*"},
+			WarnFeatOnHotfix: true,
+		},
+		Keys: KeysConfig{
+			Preset: "default",
 		},
 	}
 }
@@ -112,6 +401,29 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %v", err)
 	}
 
+	if _, err := compileTicketPattern(config.Tickets.Pattern); err != nil {
+		return nil, err
+	}
+
+	if err := validatePolicies(config.Policies, config.Tickets.Pattern); err != nil {
+		return nil, err
+	}
+
+	if err := validateBranchPatterns("excluded_branches", config.Git.ExcludedBranches); err != nil {
+		return nil, err
+	}
+	if err := validateBranchPatterns("hidden_branches", config.Git.HiddenBranches); err != nil {
+		return nil, err
+	}
+
+	if err := validatePullRequestTemplates(config.PullRequest); err != nil {
+		return nil, err
+	}
+
+	if _, err := buildKeymap(config.Keys); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
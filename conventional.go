@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// otherCommitType is the type used for commits that don't follow Conventional Commits
+const otherCommitType = "other"
+
+// ConventionalCommit is a commit message parsed per the Conventional Commits spec
+type ConventionalCommit struct {
+	Type         string // feat, fix, perf, ...
+	Scope        string
+	Subject      string
+	Breaking     bool
+	BreakingNote string // Text of a BREAKING CHANGE footer, if any
+}
+
+// conventionalSubjectPattern matches "type(scope)!: subject"
+var conventionalSubjectPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// breakingFooterPattern matches a BREAKING CHANGE footer in the message body
+var breakingFooterPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s*(.+)$`)
+
+// parseConventionalCommit parses a subject and body; ok is false if the subject isn't conventional
+func parseConventionalCommit(subject, body string) (ConventionalCommit, bool) {
+	matches := conventionalSubjectPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if matches == nil {
+		return ConventionalCommit{Subject: subject}, false
+	}
+
+	cc := ConventionalCommit{
+		Type:     strings.ToLower(matches[1]),
+		Scope:    matches[2],
+		Breaking: matches[3] == "!",
+		Subject:  matches[4],
+	}
+	if footer := breakingFooterPattern.FindStringSubmatch(body); footer != nil {
+		cc.Breaking = true
+		cc.BreakingNote = strings.TrimSpace(footer[1])
+	}
+	return cc, true
+}
+
+// commitType returns the conventional type of a commit, or "other"
+func commitType(commit Commit) string {
+	if commit.Conventional == nil {
+		return otherCommitType
+	}
+	return commit.Conventional.Type
+}
+
+// typeBadgeColors are the ANSI colors of the type badges shown in the commit list
+var typeBadgeColors = map[string]string{
+	"feat":     "\033[32m", // green
+	"fix":      "\033[33m", // yellow
+	"perf":     "\033[35m", // magenta
+	"refactor": "\033[34m", // blue
+	"revert":   "\033[31m", // red
+	"docs":     "\033[36m", // cyan
+}
+
+// renderTypeBadge returns the colored type badge for a commit, or "" for non-conventional commits
+func (cp *CherryPicker) renderTypeBadge(commit Commit) string {
+	if !cp.config.Conventional.Badges || commit.Conventional == nil {
+		return ""
+	}
+
+	color, ok := typeBadgeColors[commit.Conventional.Type]
+	if !ok {
+		color = "\033[2m" // dim for chore, test, ci, build, ...
+	}
+	badge := color + "[" + commit.Conventional.Type + "]\033[0m"
+	if commit.Conventional.Breaking {
+		badge += "\033[1;31m!\033[0m"
+	}
+	return badge + " "
+}
+
+// newTypeFilter builds the type filter set from a list of types
+func newTypeFilter(types []string) map[string]bool {
+	filter := make(map[string]bool)
+	for _, t := range types {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			filter[t] = true
+		}
+	}
+	return filter
+}
+
+// typeFilterActive reports whether the list is limited to some commit types
+func (cp *CherryPicker) typeFilterActive() bool {
+	return len(cp.typeFilter) > 0
+}
+
+// filterCommitsByType keeps the commits whose type is in the type filter
+func (cp *CherryPicker) filterCommitsByType(commits []Commit) []Commit {
+	var filtered []Commit
+	for _, commit := range commits {
+		if cp.typeFilter[commitType(commit)] {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
+
+// getCommitTypes returns the types of the loaded commits with their counts,
+// conventional types sorted by name and "other" last
+func (cp *CherryPicker) getCommitTypes() ([]string, map[string]int) {
+	counts := make(map[string]int)
+	for _, commit := range cp.commits {
+		counts[commitType(commit)]++
+	}
+	// Keep types from the filter listed so they can be turned off again
+	for t := range cp.typeFilter {
+		if _, ok := counts[t]; !ok {
+			counts[t] = 0
+		}
+	}
+
+	var types []string
+	for t := range counts {
+		if t != otherCommitType {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	if _, ok := counts[otherCommitType]; ok {
+		types = append(types, otherCommitType)
+	}
+	return types, counts
+}
+
+// enterTypeFilterMode opens the commit type filter
+func (cp *CherryPicker) enterTypeFilterMode() {
+	cp.typeFilterMode = true
+	cp.typeIndex = 0
+}
+
+// exitTypeFilterMode closes the commit type filter and resets the cursor
+func (cp *CherryPicker) exitTypeFilterMode() {
+	cp.typeFilterMode = false
+	cp.typeIndex = 0
+	cp.currentIndex = 0
+}
+
+// toggleTypeFilter adds or removes a type from the type filter
+func (cp *CherryPicker) toggleTypeFilter(t string) {
+	if cp.typeFilter == nil {
+		cp.typeFilter = make(map[string]bool)
+	}
+	if cp.typeFilter[t] {
+		delete(cp.typeFilter, t)
+	} else {
+		cp.typeFilter[t] = true
+	}
+}
+
+// typeFilterLabel describes the active type filter for the header
+func (cp *CherryPicker) typeFilterLabel() string {
+	if !cp.typeFilterActive() {
+		return "all"
+	}
+	var types []string
+	for t := range cp.typeFilter {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return strings.Join(types, ", ")
+}
+
+// isHotfixTarget reports whether the target branch matches a configured hotfix pattern
+func (cp *CherryPicker) isHotfixTarget() bool {
+	return matchesBranchPattern(cp.config.Conventional.HotfixTargets, cp.config.Git.TargetBranch)
+}
+
+// policyWarnings returns warnings about the selected commits for the current target
+func (cp *CherryPicker) policyWarnings() []string {
+	if !cp.config.Conventional.WarnFeatOnHotfix || !cp.isHotfixTarget() {
+		return nil
+	}
+
+	var features []string
+	for _, commit := range cp.getSelectedCommits() {
+		if commitType(commit) == "feat" {
+			sha := commit.SHA
+			if len(sha) > 8 {
+				sha = sha[:8]
+			}
+			features = append(features, sha)
+		}
+	}
+	if len(features) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%d feat commit(s) selected for hotfix target %s: %s",
+		len(features), cp.config.Git.TargetBranch, strings.Join(features, ", "))}
+}
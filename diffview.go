@@ -0,0 +1,578 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// diffLine is one line of a hunk
+type diffLine struct {
+	Kind byte // ' ' context, '+' added, '-' removed or '\\' for "\ No newline at end of file"
+	Text string
+	Old  int // line number in the old file, 0 for added lines
+	New  int // line number in the new file, 0 for removed lines
+}
+
+// diffHunk is one @@ section of a file diff
+type diffHunk struct {
+	Header string
+	Lines  []diffLine
+}
+
+// diffFile is the diff of one file of a commit
+type diffFile struct {
+	Path      string
+	OldPath   string   // differs from Path for renames
+	Meta      []string // mode changes, renames, "Binary files ... differ"
+	Hunks     []diffHunk
+	Added     int
+	Removed   int
+	Generated bool
+}
+
+// Files collapsed in the diff viewer by default, besides those ui.generated_files adds and
+// files marked "Code generated ... DO NOT EDIT" or "@generated"
+var defaultGeneratedFiles = []string{
+	"go.sum", "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "Cargo.lock", "Gemfile.lock",
+	"composer.lock", "poetry.lock", "*.min.js", "*.min.css", "*.map", "*.pb.go", "*_generated.go",
+	"*.generated.*", "vendor/**", "node_modules/**", "dist/**",
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// parseDiff parses git's unified diff output into files
+func parseDiff(output string) []diffFile {
+	var files []diffFile
+	var file *diffFile
+	var hunk *diffHunk
+	oldLine, newLine := 0, 0
+
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, diffFile{})
+			file = &files[len(files)-1]
+			hunk = nil
+			// "diff --git a/old b/new"; the ---/+++ lines below refine this
+			if old, new, ok := strings.Cut(strings.TrimPrefix(line, "diff --git "), " b/"); ok {
+				file.OldPath = strings.TrimPrefix(old, "a/")
+				file.Path = new
+			}
+		case file == nil:
+			continue
+		case hunk == nil && strings.HasPrefix(line, "--- "):
+			if old := strings.TrimPrefix(line, "--- "); old != "/dev/null" {
+				file.OldPath = strings.TrimPrefix(old, "a/")
+			}
+		case hunk == nil && strings.HasPrefix(line, "+++ "):
+			if new := strings.TrimPrefix(line, "+++ "); new != "/dev/null" {
+				file.Path = strings.TrimPrefix(new, "b/")
+			}
+		case strings.HasPrefix(line, "@@"):
+			file.Hunks = append(file.Hunks, diffHunk{Header: line})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+			if match := hunkHeaderPattern.FindStringSubmatch(line); match != nil {
+				fmt.Sscan(match[1], &oldLine)
+				fmt.Sscan(match[2], &newLine)
+			}
+		case hunk == nil:
+			if !strings.HasPrefix(line, "index ") {
+				file.Meta = append(file.Meta, line)
+			}
+		case strings.HasPrefix(line, "+"):
+			hunk.Lines = append(hunk.Lines, diffLine{Kind: '+', Text: line[1:], New: newLine})
+			file.Added++
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			hunk.Lines = append(hunk.Lines, diffLine{Kind: '-', Text: line[1:], Old: oldLine})
+			file.Removed++
+			oldLine++
+		case strings.HasPrefix(line, "\\"):
+			hunk.Lines = append(hunk.Lines, diffLine{Kind: '\\', Text: line})
+		default:
+			hunk.Lines = append(hunk.Lines, diffLine{Kind: ' ', Text: strings.TrimPrefix(line, " "), Old: oldLine, New: newLine})
+			oldLine++
+			newLine++
+		}
+	}
+	return files
+}
+
+// isGeneratedFile reports whether a file matches one of the generated file patterns, or says
+// it's generated in its first lines
+func isGeneratedFile(file diffFile, patterns []string) bool {
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if strings.HasPrefix(file.Path, dir+"/") || strings.Contains(file.Path, "/"+dir+"/") {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(pattern, file.Path); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, path.Base(file.Path)); matched {
+			return true
+		}
+	}
+
+	if len(file.Hunks) > 0 {
+		for i, line := range file.Hunks[0].Lines {
+			if i >= 5 {
+				break
+			}
+			if strings.Contains(line.Text, "@generated") || (strings.Contains(line.Text, "Code generated") && strings.Contains(line.Text, "DO NOT EDIT")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Kinds of rows in the diff viewer
+const (
+	diffRowFile = iota
+	diffRowMeta
+	diffRowHunk
+	diffRowLine
+	diffRowCollapsed
+)
+
+// diffRow is one screen row of the diff viewer. Unified rows use Left only; side-by-side rows
+// put the old line on the Left and the new one on the Right. Pair is the line a changed line
+// is compared with for intra-line highlights.
+type diffRow struct {
+	Kind  int
+	File  int
+	Text  string
+	Left  *diffLine
+	Right *diffLine
+	Pair  *diffLine
+}
+
+// openDiffView opens the full-screen diff viewer on the commit under the cursor
+func (cp *CherryPicker) openDiffView() {
+	commit := cp.getCurrentCommit()
+	if commit == nil {
+		return
+	}
+
+	cp.diffViewMode = true
+	cp.diffViewCommit = commit
+	cp.diffScroll = 0
+	cp.diffToggled = make(map[int]bool)
+	cp.diffViewError = ""
+	cp.diffFiles = nil
+
+	// Merges are shown against their first parent, the side cherry-pick -m 1 applies
+	args := append([]string{"show", "--format=", "--patch", "-M", "-m", "--first-parent", commit.SHA}, cp.pathArgs()...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		cp.diffViewError = fmt.Sprintf("failed to load diff: %v", err)
+		return
+	}
+
+	patterns := append(append([]string{}, defaultGeneratedFiles...), cp.config.UI.GeneratedFiles...)
+	cp.diffFiles = parseDiff(string(output))
+	for i := range cp.diffFiles {
+		cp.diffFiles[i].Generated = isGeneratedFile(cp.diffFiles[i], patterns)
+	}
+}
+
+// closeDiffView closes the diff viewer
+func (cp *CherryPicker) closeDiffView() {
+	cp.diffViewMode = false
+	cp.diffViewCommit = nil
+	cp.diffFiles = nil
+	cp.diffToggled = nil
+}
+
+// diffFileCollapsed reports whether a file's lines are hidden: generated files and files with
+// more changed lines than ui.diff_collapse_lines start collapsed, and Enter flips that
+func (cp *CherryPicker) diffFileCollapsed(index int) bool {
+	file := cp.diffFiles[index]
+	limit := cp.config.UI.DiffCollapseLines
+	collapsed := file.Generated || (limit > 0 && file.Added+file.Removed > limit)
+	return collapsed != cp.diffToggled[index]
+}
+
+// diffRows lays the diff out as screen rows
+func (cp *CherryPicker) diffRows() []diffRow {
+	var rows []diffRow
+	for f, file := range cp.diffFiles {
+		rows = append(rows, diffRow{Kind: diffRowFile, File: f})
+		for _, meta := range file.Meta {
+			rows = append(rows, diffRow{Kind: diffRowMeta, File: f, Text: meta})
+		}
+		if cp.diffFileCollapsed(f) {
+			if len(file.Hunks) > 0 {
+				rows = append(rows, diffRow{Kind: diffRowCollapsed, File: f})
+			}
+			continue
+		}
+		for h := range file.Hunks {
+			hunk := &cp.diffFiles[f].Hunks[h]
+			rows = append(rows, diffRow{Kind: diffRowHunk, File: f, Text: hunk.Header})
+			rows = append(rows, cp.hunkRows(f, hunk)...)
+		}
+	}
+	return rows
+}
+
+// hunkRows lays out the lines of a hunk. Runs of removed lines followed by added lines are
+// paired up line by line, side by side or for intra-line highlights.
+func (cp *CherryPicker) hunkRows(file int, hunk *diffHunk) []diffRow {
+	var rows []diffRow
+	lines := hunk.Lines
+	for i := 0; i < len(lines); {
+		if lines[i].Kind != '-' && lines[i].Kind != '+' {
+			line := &lines[i]
+			if cp.diffSideBySide {
+				rows = append(rows, diffRow{Kind: diffRowLine, File: file, Left: line, Right: line})
+			} else {
+				rows = append(rows, diffRow{Kind: diffRowLine, File: file, Left: line})
+			}
+			i++
+			continue
+		}
+
+		// Collect a change block: removed lines, then added lines
+		var removed, added []*diffLine
+		for i < len(lines) && lines[i].Kind == '-' {
+			removed = append(removed, &lines[i])
+			i++
+		}
+		for i < len(lines) && lines[i].Kind == '+' {
+			added = append(added, &lines[i])
+			i++
+		}
+
+		if cp.diffSideBySide {
+			for k := 0; k < max(len(removed), len(added)); k++ {
+				row := diffRow{Kind: diffRowLine, File: file}
+				if k < len(removed) {
+					row.Left = removed[k]
+				}
+				if k < len(added) {
+					row.Right = added[k]
+				}
+				rows = append(rows, row)
+			}
+			continue
+		}
+		for k, line := range removed {
+			row := diffRow{Kind: diffRowLine, File: file, Left: line}
+			if k < len(added) {
+				row.Pair = added[k]
+			}
+			rows = append(rows, row)
+		}
+		for k, line := range added {
+			row := diffRow{Kind: diffRowLine, File: file, Left: line}
+			if k < len(removed) {
+				row.Pair = removed[k]
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// diffViewControls returns the diff viewer's controls wrapped to the window
+func (cp *CherryPicker) diffViewControls() string {
+	width, _ := cp.windowSize()
+	return wrapText(cp.controlsLine(keyModeDiff), width)
+}
+
+// diffViewHeight returns the number of diff rows shown at once: the window less the two title
+// lines, a blank line and the controls
+func (cp *CherryPicker) diffViewHeight() int {
+	_, height := cp.windowSize()
+	return max(height-3-lineCount(cp.diffViewControls()), 1)
+}
+
+// scrollDiff scrolls the diff viewer by delta rows; renderDiffView keeps it in bounds
+func (cp *CherryPicker) scrollDiff(delta int) {
+	cp.diffScroll = max(cp.diffScroll+delta, 0)
+}
+
+// jumpDiff moves the top row to the next (forward) or previous row of the given kind
+func (cp *CherryPicker) jumpDiff(kind int, forward bool) {
+	rows := cp.diffRows()
+	if forward {
+		for i := cp.diffScroll + 1; i < len(rows); i++ {
+			if rows[i].Kind == kind {
+				cp.diffScroll = i
+				return
+			}
+		}
+		return
+	}
+	for i := min(cp.diffScroll, len(rows)) - 1; i >= 0; i-- {
+		if rows[i].Kind == kind {
+			cp.diffScroll = i
+			return
+		}
+	}
+}
+
+// currentDiffFile returns the index of the file at the top of the viewer, or -1
+func (cp *CherryPicker) currentDiffFile() int {
+	rows := cp.diffRows()
+	if len(rows) == 0 {
+		return -1
+	}
+	return rows[min(cp.diffScroll, len(rows)-1)].File
+}
+
+// toggleDiffFile collapses or expands the file at the top of the viewer
+func (cp *CherryPicker) toggleDiffFile() {
+	file := cp.currentDiffFile()
+	if file < 0 {
+		return
+	}
+	cp.diffToggled[file] = !cp.diffToggled[file]
+
+	// Keep the file's header at the top
+	for i, row := range cp.diffRows() {
+		if row.File == file {
+			cp.diffScroll = i
+			break
+		}
+	}
+}
+
+// toggleDiffLayout switches between unified and side-by-side, keeping the current file in view
+func (cp *CherryPicker) toggleDiffLayout() {
+	file := cp.currentDiffFile()
+	cp.diffSideBySide = !cp.diffSideBySide
+	for i, row := range cp.diffRows() {
+		if row.File == file {
+			cp.diffScroll = i
+			break
+		}
+	}
+}
+
+// handleDiffViewInput handles keyboard input in the diff viewer
+func (cp *CherryPicker) handleDiffViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := cp.diffViewHeight()
+	switch cp.keyAction(keyModeDiff, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "close":
+		cp.closeDiffView()
+	case "down":
+		cp.scrollDiff(1)
+	case "up":
+		cp.scrollDiff(-1)
+	case "page_down":
+		cp.scrollDiff(page)
+	case "page_up":
+		cp.scrollDiff(-page)
+	case "half_down":
+		cp.scrollDiff(page / 2)
+	case "half_up":
+		cp.scrollDiff(-page / 2)
+	case "top":
+		cp.diffScroll = 0
+	case "bottom":
+		cp.diffScroll = len(cp.diffRows())
+	case "next_hunk":
+		cp.jumpDiff(diffRowHunk, true)
+	case "prev_hunk":
+		cp.jumpDiff(diffRowHunk, false)
+	case "next_file":
+		cp.jumpDiff(diffRowFile, true)
+	case "prev_file":
+		cp.jumpDiff(diffRowFile, false)
+	case "collapse_file":
+		cp.toggleDiffFile()
+	case "side_by_side":
+		cp.toggleDiffLayout()
+	case "help":
+		cp.openHelp()
+	}
+	return cp, nil
+}
+
+// renderDiffView renders the full-screen diff viewer
+func (cp *CherryPicker) renderDiffView() string {
+	width, _ := cp.windowSize()
+	var s strings.Builder
+
+	title := "📄 Diff"
+	if cp.diffViewCommit != nil {
+		title += ": " + cp.diffViewCommit.Full
+	}
+	s.WriteString(fitLines([]string{title}, width, 1)[0] + "\n")
+
+	if cp.diffViewError != "" {
+		s.WriteString("\n❌ " + cp.diffViewError + "\n\nPress " + cp.keyFor(keyModeDiff, "close") + " to go back.")
+		return s.String()
+	}
+	if len(cp.diffFiles) == 0 {
+		s.WriteString("\nNo changes to show.\n\nPress " + cp.keyFor(keyModeDiff, "close") + " to go back.")
+		return s.String()
+	}
+
+	rows := cp.diffRows()
+	height := cp.diffViewHeight()
+	cp.diffScroll = min(cp.diffScroll, max(len(rows)-height, 0))
+	end := min(cp.diffScroll+height, len(rows))
+
+	// Where we are: the file at the top, and the rows shown
+	file := rows[cp.diffScroll].File
+	layout := "unified"
+	if cp.diffSideBySide {
+		layout = "side-by-side"
+	}
+	position := fmt.Sprintf("File %d/%d: %s · rows %d-%d of %d · %s", file+1, len(cp.diffFiles), cp.diffFiles[file].Path,
+		cp.diffScroll+1, end, len(rows), layout)
+	s.WriteString(fitLines([]string{"\033[2m" + position + "\033[0m"}, width, 1)[0] + "\n")
+
+	var lines []string
+	for _, row := range rows[cp.diffScroll:end] {
+		lines = append(lines, cp.renderDiffRow(row, width))
+	}
+	s.WriteString(strings.Join(fitLines(lines, width, height), "\n"))
+
+	s.WriteString("\n\n" + cp.diffViewControls())
+	return s.String()
+}
+
+// Diff line backgrounds, and the stronger ones marking changed words (256-color palette)
+const (
+	diffAddedBackground   = 22
+	diffAddedHighlight    = 28
+	diffRemovedBackground = 52
+	diffRemovedHighlight  = 88
+)
+
+// renderDiffRow renders one row of the diff viewer
+func (cp *CherryPicker) renderDiffRow(row diffRow, width int) string {
+	file := cp.diffFiles[row.File]
+	switch row.Kind {
+	case diffRowFile:
+		name := file.Path
+		if file.OldPath != "" && file.OldPath != file.Path {
+			name = file.OldPath + " → " + file.Path
+		}
+		label := fmt.Sprintf("━━ 📄 %s (+%d -%d)", name, file.Added, file.Removed)
+		if file.Generated {
+			label += " [generated]"
+		}
+		return "\033[1m" + label + "\033[0m"
+	case diffRowMeta:
+		return "\033[2m   " + row.Text + "\033[0m"
+	case diffRowHunk:
+		return "\033[36m" + row.Text + "\033[0m"
+	case diffRowCollapsed:
+		reason := "collapsed"
+		if limit := cp.config.UI.DiffCollapseLines; limit > 0 && file.Added+file.Removed > limit {
+			reason = "large file"
+		}
+		if file.Generated {
+			reason = "generated file"
+		}
+		return fmt.Sprintf("\033[2m   ▸ %d changed lines hidden (%s); %s expands\033[0m", file.Added+file.Removed, reason,
+			cp.keyFor(keyModeDiff, "collapse_file"))
+	}
+
+	lang := languageForFile(file.Path)
+	if !cp.diffSideBySide {
+		return renderDiffSide(lang, row.Left, row.Pair, width, diffColumnUnified)
+	}
+
+	// Two columns with a divider; each column has its own line numbers
+	half := (width - 3) / 2
+	left := fitLines([]string{renderDiffSide(lang, row.Left, row.Right, half, diffColumnOld)}, half, 1)[0]
+	right := renderDiffSide(lang, row.Right, row.Left, width-3-half, diffColumnNew)
+	return left + " │ " + right
+}
+
+// Columns a diff line is rendered in, which decide the line numbers shown
+const (
+	diffColumnUnified = iota // old and new line numbers
+	diffColumnOld
+	diffColumnNew
+)
+
+// renderDiffSide renders a diff line with line numbers, syntax colors and, if it is a change
+// paired with another line, the changed words highlighted
+func renderDiffSide(lang *syntaxLang, line, pair *diffLine, width, column int) string {
+	if line == nil {
+		return ""
+	}
+	if line.Kind == '\\' {
+		return "\033[2m" + line.Text + "\033[0m"
+	}
+
+	number := func(n int) string {
+		if n == 0 {
+			return "    "
+		}
+		return fmt.Sprintf("%4d", n)
+	}
+	var gutter string
+	switch column {
+	case diffColumnOld:
+		gutter = number(line.Old)
+	case diffColumnNew:
+		gutter = number(line.New)
+	default:
+		gutter = number(line.Old) + " " + number(line.New)
+	}
+	prefix := "\033[2m" + gutter + "\033[0m " + string(line.Kind) + " "
+
+	// Only the part of the line that fits is highlighted
+	text := []rune(strings.ReplaceAll(line.Text, "\t", "    "))
+	if room := width - len(gutter) - 3; room >= 0 && len(text) > room {
+		text = text[:room]
+	}
+
+	var marks []bool
+	background, highlight := syntaxNone, syntaxNone
+	switch line.Kind {
+	case '+':
+		background, highlight = diffAddedBackground, diffAddedHighlight
+		if pair != nil && pair.Kind == '-' {
+			_, marks = wordDiff([]rune(pair.Text), []rune(line.Text))
+		}
+	case '-':
+		background, highlight = diffRemovedBackground, diffRemovedHighlight
+		if pair != nil && pair.Kind == '+' {
+			marks, _ = wordDiff([]rune(line.Text), []rune(pair.Text))
+		}
+	}
+	marks = expandTabMarks(line.Text, marks, len(text))
+
+	return prefix + styleRunes(text, highlightSyntax(lang, text), marks, background, highlight)
+}
+
+// expandTabMarks maps marks on the runes of text to its tab-expanded runes, cut to length
+func expandTabMarks(text string, marks []bool, length int) []bool {
+	if marks == nil {
+		return nil
+	}
+	expanded := make([]bool, 0, length)
+	for i, r := range []rune(text) {
+		width := 1
+		if r == '\t' {
+			width = 4
+		}
+		for k := 0; k < width; k++ {
+			expanded = append(expanded, marks[i])
+		}
+	}
+	if len(expanded) > length {
+		expanded = expanded[:length]
+	}
+	return expanded
+}
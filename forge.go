@@ -0,0 +1,459 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const defaultPullRequestTitleTemplate = `[{{.Target}}] {{.Title}}`
+
+const defaultPullRequestBodyTemplate = `Backport of {{len .Commits}} commit(s) from ` + "`{{.Source}}`" + ` to ` + "`{{.Target}}`" + `.
+
+{{range .Commits}}- {{.SHA}} {{.Message}}{{if .Author}} ({{.Author}}){{end}}
+{{end}}`
+
+// PullRequest describes a pull/merge request to be opened on a forge
+type PullRequest struct {
+	Title string
+	Body  string
+	Head  string // Branch containing the picked commits
+	Base  string // Branch the request should merge into
+
+	// HeadRepository is the "owner/repo" holding Head when it is a fork of the base
+	// repository, or "" when both branches live in the same repository
+	HeadRepository string
+}
+
+// PullRequestResult is what the forge returned for a created request
+type PullRequestResult struct {
+	Number int
+	URL    string
+}
+
+// ForgeClient opens pull/merge requests on a hosted git forge
+type ForgeClient interface {
+	CreatePullRequest(pr PullRequest) (*PullRequestResult, error)
+}
+
+// pullRequestTemplateData is the data passed to the title and body templates
+type pullRequestTemplateData struct {
+	Source  string
+	Target  string
+	Branch  string
+	Title   string
+	Commits []Commit
+}
+
+// NewForgeClient builds a forge client from configuration, reading the token from the environment
+func NewForgeClient(cfg PullRequestConfig, repository string) (ForgeClient, error) {
+	forge := strings.ToLower(cfg.Forge)
+	if forge == "" {
+		forge = "github"
+	}
+
+	tokenEnv := cfg.TokenEnv
+	if tokenEnv == "" {
+		tokenEnv = strings.ToUpper(forge) + "_TOKEN"
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("no API token found in $%s", tokenEnv)
+	}
+
+	if repository == "" {
+		return nil, fmt.Errorf("no repository configured for %s", forge)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+
+	switch forge {
+	case "github":
+		if baseURL == "" {
+			baseURL = "https://api.github.com"
+		}
+		return &githubClient{baseURL: baseURL, repository: repository, token: token, http: httpClient}, nil
+	case "gitlab":
+		if baseURL == "" {
+			baseURL = "https://gitlab.com"
+		}
+		return &gitlabClient{baseURL: baseURL, repository: repository, token: token, http: httpClient}, nil
+	case "gitea":
+		if baseURL == "" {
+			return nil, fmt.Errorf("base_url is required for gitea")
+		}
+		return &giteaClient{baseURL: baseURL, repository: repository, token: token, http: httpClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown forge: %s", cfg.Forge)
+	}
+}
+
+// githubClient talks to the GitHub REST API
+type githubClient struct {
+	baseURL    string
+	repository string
+	token      string
+	http       *http.Client
+}
+
+func (c *githubClient) CreatePullRequest(pr PullRequest) (*PullRequestResult, error) {
+	payload := map[string]string{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"head":  forkHead(pr),
+		"base":  pr.Base,
+	}
+	headers := map[string]string{
+		"Authorization": "Bearer " + c.token,
+		"Accept":        "application/vnd.github+json",
+	}
+
+	var response struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls", c.baseURL, c.repository)
+	if err := postJSON(c.http, endpoint, headers, payload, &response); err != nil {
+		return nil, err
+	}
+	return &PullRequestResult{Number: response.Number, URL: response.HTMLURL}, nil
+}
+
+// gitlabClient talks to the GitLab REST API
+type gitlabClient struct {
+	baseURL    string
+	repository string
+	token      string
+	http       *http.Client
+}
+
+func (c *gitlabClient) CreatePullRequest(pr PullRequest) (*PullRequestResult, error) {
+	payload := map[string]interface{}{
+		"title":         pr.Title,
+		"description":   pr.Body,
+		"source_branch": pr.Head,
+		"target_branch": pr.Base,
+	}
+	headers := map[string]string{
+		"PRIVATE-TOKEN": c.token,
+	}
+
+	// Merge requests from a fork are created on the fork, pointing at the target project
+	project := c.repository
+	if pr.HeadRepository != "" && pr.HeadRepository != c.repository {
+		var target struct {
+			ID int `json:"id"`
+		}
+		endpoint := fmt.Sprintf("%s/api/v4/projects/%s", c.baseURL, url.PathEscape(c.repository))
+		if err := requestJSON(c.http, http.MethodGet, endpoint, headers, nil, &target); err != nil {
+			return nil, fmt.Errorf("failed to look up project %s: %v", c.repository, err)
+		}
+		payload["target_project_id"] = target.ID
+		project = pr.HeadRepository
+	}
+
+	var response struct {
+		IID    int    `json:"iid"`
+		WebURL string `json:"web_url"`
+	}
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests", c.baseURL, url.PathEscape(project))
+	if err := postJSON(c.http, endpoint, headers, payload, &response); err != nil {
+		return nil, err
+	}
+	return &PullRequestResult{Number: response.IID, URL: response.WebURL}, nil
+}
+
+// giteaClient talks to the Gitea (and Forgejo) REST API
+type giteaClient struct {
+	baseURL    string
+	repository string
+	token      string
+	http       *http.Client
+}
+
+func (c *giteaClient) CreatePullRequest(pr PullRequest) (*PullRequestResult, error) {
+	payload := map[string]string{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"head":  forkHead(pr),
+		"base":  pr.Base,
+	}
+	headers := map[string]string{
+		"Authorization": "token " + c.token,
+	}
+
+	var response struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	endpoint := fmt.Sprintf("%s/api/v1/repos/%s/pulls", c.baseURL, c.repository)
+	if err := postJSON(c.http, endpoint, headers, payload, &response); err != nil {
+		return nil, err
+	}
+	return &PullRequestResult{Number: response.Number, URL: response.HTMLURL}, nil
+}
+
+// forkHead returns the head reference for GitHub and Gitea, "owner:branch" when the
+// branch lives in a fork
+func forkHead(pr PullRequest) string {
+	if pr.HeadRepository == "" {
+		return pr.Head
+	}
+	owner, _, _ := strings.Cut(pr.HeadRepository, "/")
+	return owner + ":" + pr.Head
+}
+
+// postJSON sends a JSON payload and decodes the JSON response into out
+func postJSON(client *http.Client, endpoint string, headers map[string]string, payload interface{}, out interface{}) error {
+	return requestJSON(client, http.MethodPost, endpoint, headers, payload, out)
+}
+
+// requestJSON sends a request with an optional JSON payload and decodes the JSON response into out
+func requestJSON(client *http.Client, method, endpoint string, headers map[string]string, payload interface{}, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %v", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %v", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("forge returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %v", err)
+	}
+	return nil
+}
+
+// remoteRepositoryPattern matches the owner/repo path in SSH and HTTPS remote URLs
+var remoteRepositoryPattern = regexp.MustCompile(`^(?:[a-z+]+://)?(?:[^@/]+@)?[^:/]+(?::\d+)?[:/](.+?)(?:\.git)?/?$`)
+
+// parseRemoteRepository extracts "owner/repo" from a remote URL
+func parseRemoteRepository(remoteURL string) string {
+	matches := remoteRepositoryPattern.FindStringSubmatch(strings.TrimSpace(remoteURL))
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// slugify turns a commit subject into a branch-safe topic name
+func slugify(text string) string {
+	var b strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastDash = false
+		} else if !lastDash {
+			b.WriteRune('-')
+			lastDash = true
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// commitsForSHAs returns the loaded commits matching the given SHAs, in SHA order
+func (cp *CherryPicker) commitsForSHAs(shas []string) []Commit {
+	var commits []Commit
+	for _, sha := range shas {
+		found := Commit{SHA: sha}
+		for _, commit := range cp.commits {
+			if sameCommit(commit.SHA, sha) {
+				found = commit
+				break
+			}
+		}
+		commits = append(commits, found)
+	}
+	return commits
+}
+
+// backportBranchName returns the <prefix>/<target>/<topic> branch for a run
+func (cp *CherryPicker) backportBranchName(commits []Commit) string {
+	prefix := cp.config.PullRequest.BranchPrefix
+	if prefix == "" {
+		prefix = "backport"
+	}
+
+	topic := ""
+	if len(commits) > 0 {
+		topic = slugify(commits[0].Message)
+		sha := commits[0].SHA
+		if len(sha) > 8 {
+			sha = sha[:8]
+		}
+		if topic == "" {
+			topic = sha
+		} else {
+			topic = sha + "-" + topic
+		}
+	}
+	if topic == "" {
+		topic = time.Now().Format("20060102-150405")
+	}
+
+	return fmt.Sprintf("%s/%s/%s", prefix, cp.config.Git.TargetBranch, topic)
+}
+
+// renderPullRequestTemplate executes a title or body template
+func renderPullRequestTemplate(name, text string, data pullRequestTemplateData) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %v", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %v", name, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// validatePullRequestTemplates checks that the configured title and body templates parse
+func validatePullRequestTemplates(prConfig PullRequestConfig) error {
+	if _, err := template.New("title").Parse(prConfig.TitleTemplate); err != nil {
+		return fmt.Errorf("pull_request.title_template: %v", err)
+	}
+	if _, err := template.New("body").Parse(prConfig.BodyTemplate); err != nil {
+		return fmt.Errorf("pull_request.body_template: %v", err)
+	}
+	return nil
+}
+
+// remoteRepository returns the "owner/repo" path of a remote's URL
+func remoteRepository(remote string) (string, error) {
+	output, err := exec.Command("git", "remote", "get-url", remote).Output()
+	if err != nil {
+		return "", fmt.Errorf("could not get URL of remote '%s': %v", remote, err)
+	}
+	return parseRemoteRepository(string(output)), nil
+}
+
+// openPullRequest pushes the backport branch holding the picked commits and opens a
+// pull/merge request for it
+func (cp *CherryPicker) openPullRequest(shas []string) error {
+	prConfig := cp.config.PullRequest
+	remote := cp.pushRemote()
+	commits := cp.commitsForSHAs(shas)
+	branch := cp.backportBranch
+	if branch == "" {
+		branch = cp.backportBranchName(commits)
+	}
+
+	// The request targets the repository of the target remote
+	repository := prConfig.Repository
+	if repository == "" {
+		var err error
+		if repository, err = remoteRepository(cp.targetRemote()); err != nil {
+			return err
+		}
+	}
+
+	// Backport branches pushed to a fork are opened as cross-repository requests
+	headRepository := ""
+	if remote != cp.targetRemote() {
+		var err error
+		if headRepository, err = remoteRepository(remote); err != nil {
+			return err
+		}
+	}
+
+	client, err := NewForgeClient(prConfig, repository)
+	if err != nil {
+		return err
+	}
+
+	data := pullRequestTemplateData{
+		Source:  cp.config.Git.SourceBranch,
+		Target:  cp.config.Git.TargetBranch,
+		Branch:  branch,
+		Commits: commits,
+	}
+	if len(commits) == 1 {
+		data.Title = commits[0].Message
+	} else {
+		data.Title = fmt.Sprintf("Backport %d commits from %s", len(commits), data.Source)
+	}
+
+	titleTemplate := prConfig.TitleTemplate
+	if titleTemplate == "" {
+		titleTemplate = defaultPullRequestTitleTemplate
+	}
+	bodyTemplate := prConfig.BodyTemplate
+	if bodyTemplate == "" {
+		bodyTemplate = defaultPullRequestBodyTemplate
+	}
+
+	title, err := renderPullRequestTemplate("title", titleTemplate, data)
+	if err != nil {
+		return err
+	}
+	body, err := renderPullRequestTemplate("body", bodyTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	// Push only once the request can be written, so a bad template leaves nothing behind
+	fmt.Printf("🚀 Pushing to %s/%s...\n", remote, branch)
+	output, err := exec.Command("git", "push", remote, "HEAD:refs/heads/"+branch).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to push %s: %v\n%s", branch, err, strings.TrimSpace(string(output)))
+	}
+
+	fmt.Println("📣 Opening pull request...")
+	result, err := client.CreatePullRequest(PullRequest{
+		Title: title,
+		Body:  body,
+		Head:  branch,
+		Base:  cp.config.Git.TargetBranch,
+
+		HeadRepository: headRepository,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open pull request: %v", err)
+	}
+
+	fmt.Printf("✅ Opened #%d: %s\n", result.Number, result.URL)
+	cp.pullRequestURL = result.URL
+	return nil
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// forgeRequest is a request received by the stub forge
+type forgeRequest struct {
+	Method  string
+	Path    string
+	Headers http.Header
+	Payload map[string]interface{}
+}
+
+// newStubForge starts an HTTP server that records requests and answers with status and body
+func newStubForge(t *testing.T, status int, body string) (*httptest.Server, *[]forgeRequest) {
+	t.Helper()
+	var requests []forgeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		payload := map[string]interface{}{}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &payload); err != nil {
+				t.Errorf("request body is not JSON: %v", err)
+			}
+		}
+		requests = append(requests, forgeRequest{Method: r.Method, Path: r.URL.EscapedPath(), Headers: r.Header, Payload: payload})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestForgeClientsCreatePullRequest(t *testing.T) {
+	tests := []struct {
+		forge      string
+		response   string
+		wantPath   string
+		wantHeader string
+		wantValue  string
+		wantHead   string
+		headField  string
+		wantNumber int
+	}{
+		{
+			forge:      "github",
+			response:   `{"number": 12, "html_url": "https://example.test/pull/12"}`,
+			wantPath:   "/repos/owner/repo/pulls",
+			wantHeader: "Authorization",
+			wantValue:  "Bearer secret",
+			headField:  "head",
+			wantHead:   "backport/release/abc",
+			wantNumber: 12,
+		},
+		{
+			forge:      "gitlab",
+			response:   `{"iid": 5, "web_url": "https://example.test/merge_requests/5"}`,
+			wantPath:   "/api/v4/projects/owner%2Frepo/merge_requests",
+			wantHeader: "Private-Token",
+			wantValue:  "secret",
+			headField:  "source_branch",
+			wantHead:   "backport/release/abc",
+			wantNumber: 5,
+		},
+		{
+			forge:      "gitea",
+			response:   `{"number": 3, "html_url": "https://example.test/pulls/3"}`,
+			wantPath:   "/api/v1/repos/owner/repo/pulls",
+			wantHeader: "Authorization",
+			wantValue:  "token secret",
+			headField:  "head",
+			wantHead:   "backport/release/abc",
+			wantNumber: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.forge, func(t *testing.T) {
+			server, requests := newStubForge(t, http.StatusCreated, tt.response)
+			t.Setenv("TEST_FORGE_TOKEN", "secret")
+
+			client, err := NewForgeClient(PullRequestConfig{Forge: tt.forge, BaseURL: server.URL, TokenEnv: "TEST_FORGE_TOKEN"}, "owner/repo")
+			if err != nil {
+				t.Fatalf("NewForgeClient: %v", err)
+			}
+			result, err := client.CreatePullRequest(PullRequest{Title: "title", Body: "body", Head: "backport/release/abc", Base: "release"})
+			if err != nil {
+				t.Fatalf("CreatePullRequest: %v", err)
+			}
+
+			if result.Number != tt.wantNumber {
+				t.Errorf("number = %d, want %d", result.Number, tt.wantNumber)
+			}
+			if len(*requests) != 1 {
+				t.Fatalf("got %d requests, want 1", len(*requests))
+			}
+			req := (*requests)[0]
+			if req.Method != http.MethodPost || req.Path != tt.wantPath {
+				t.Errorf("request = %s %s, want POST %s", req.Method, req.Path, tt.wantPath)
+			}
+			if got := req.Headers.Get(tt.wantHeader); got != tt.wantValue {
+				t.Errorf("%s = %q, want %q", tt.wantHeader, got, tt.wantValue)
+			}
+			if got := req.Payload[tt.headField]; got != tt.wantHead {
+				t.Errorf("%s = %v, want %q", tt.headField, got, tt.wantHead)
+			}
+		})
+	}
+}
+
+func TestForgeClientReportsErrors(t *testing.T) {
+	server, _ := newStubForge(t, http.StatusUnprocessableEntity, `{"message": "No commits between release and backport"}`)
+	t.Setenv("TEST_FORGE_TOKEN", "secret")
+
+	client, err := NewForgeClient(PullRequestConfig{Forge: "github", BaseURL: server.URL, TokenEnv: "TEST_FORGE_TOKEN"}, "owner/repo")
+	if err != nil {
+		t.Fatalf("NewForgeClient: %v", err)
+	}
+	_, err = client.CreatePullRequest(PullRequest{Head: "backport/release/abc", Base: "release"})
+	if err == nil || !strings.Contains(err.Error(), "422") {
+		t.Fatalf("err = %v, want a 422 error", err)
+	}
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// newPullRequestRepo creates a clone of a bare origin with a release branch and one
+// commit on main to backport, and changes into it
+func newPullRequestRepo(t *testing.T) (work, origin, fix string) {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.test")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.test")
+
+	root := t.TempDir()
+	origin = filepath.Join(root, "origin.git")
+	work = filepath.Join(root, "work")
+	git(t, root, "init", "--quiet", "--bare", "--initial-branch=main", origin)
+	git(t, root, "clone", "--quiet", origin, work)
+
+	os.WriteFile(filepath.Join(work, "app.txt"), []byte("one\n"), 0644)
+	git(t, work, "add", ".")
+	git(t, work, "commit", "--quiet", "-m", "Initial commit")
+	git(t, work, "branch", "release")
+	git(t, work, "push", "--quiet", "origin", "main", "release")
+
+	os.WriteFile(filepath.Join(work, "app.txt"), []byte("two\n"), 0644)
+	git(t, work, "commit", "--quiet", "-am", "Fix the thing")
+	fix = git(t, work, "rev-parse", "HEAD")
+
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(work); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+	return work, origin, fix
+}
+
+// newPullRequestPicker returns a picker configured for a pull request run against server
+func newPullRequestPicker(serverURL string) *CherryPicker {
+	config := DefaultConfig()
+	config.Git.SourceBranch = "main"
+	config.Git.TargetBranch = "release"
+	config.Git.AutoFetch = false
+	config.Behavior.AutoPush = true
+	config.Behavior.PreviewPush = false
+	config.Ledger.Enabled = false
+	config.Notes.Enabled = false
+	config.PullRequest.Enabled = true
+	config.PullRequest.BaseURL = serverURL
+	config.PullRequest.Repository = "owner/repo"
+	config.PullRequest.TokenEnv = "TEST_FORGE_TOKEN"
+	config.Hooks.PostRun = []string{"touch post-run-ran"}
+	return &CherryPicker{config: config, selected: map[string]bool{}}
+}
+
+func TestPullRequestRunPicksOntoBackportBranch(t *testing.T) {
+	server, requests := newStubForge(t, http.StatusCreated, `{"number": 7, "html_url": "https://example.test/pull/7"}`)
+	t.Setenv("TEST_FORGE_TOKEN", "secret")
+	work, origin, fix := newPullRequestRepo(t)
+	releaseBefore := git(t, work, "rev-parse", "release")
+
+	cp := newPullRequestPicker(server.URL)
+	if err := cp.cherryPickWithConflictHandling([]string{fix}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	branch := cp.backportBranch
+	if !strings.HasPrefix(branch, "backport/release/") {
+		t.Fatalf("backport branch = %q, want backport/release/...", branch)
+	}
+	if got := git(t, work, "rev-parse", "release"); got != releaseBefore {
+		t.Errorf("local release moved to %s", got)
+	}
+	if got := git(t, origin, "rev-parse", "release"); got != releaseBefore {
+		t.Errorf("remote release moved to %s", got)
+	}
+	if got := git(t, origin, "log", "--format=%s", "release.."+branch); got != "Fix the thing" {
+		t.Errorf("remote %s adds %q, want the picked commit", branch, got)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("got %d forge requests, want 1", len(*requests))
+	}
+	payload := (*requests)[0].Payload
+	if payload["head"] != branch || payload["base"] != "release" {
+		t.Errorf("pull request head/base = %v/%v, want %s/release", payload["head"], payload["base"], branch)
+	}
+	if _, err := os.Stat(filepath.Join(work, "post-run-ran")); err != nil {
+		t.Errorf("post_run hook did not run: %v", err)
+	}
+	if !cp.runPushed || cp.currentRun().PullRequest != "https://example.test/pull/7" {
+		t.Errorf("run pushed = %v with pull request %q, want it pushed with the opened request", cp.runPushed, cp.currentRun().PullRequest)
+	}
+}
+
+func TestPullRequestRunWritesNoBackportNotes(t *testing.T) {
+	server, _ := newStubForge(t, http.StatusCreated, `{"number": 7, "html_url": "https://example.test/pull/7"}`)
+	t.Setenv("TEST_FORGE_TOKEN", "secret")
+	work, origin, fix := newPullRequestRepo(t)
+
+	cp := newPullRequestPicker(server.URL)
+	cp.config.Notes.Enabled = true
+	cp.config.Notes.Sync = true
+	if err := cp.cherryPickWithConflictHandling([]string{fix}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	if got := git(t, work, "notes", "--ref="+cp.notesRef(), "list"); got != "" {
+		t.Errorf("local notes = %q, want none for an unmerged pull request", got)
+	}
+	if got := git(t, origin, "for-each-ref", "refs/notes/"); got != "" {
+		t.Errorf("remote notes = %q, want none pushed", got)
+	}
+}
+
+func TestPullRequestBadTemplatePushesNothing(t *testing.T) {
+	server, requests := newStubForge(t, http.StatusCreated, `{"number": 7, "html_url": "https://example.test/pull/7"}`)
+	t.Setenv("TEST_FORGE_TOKEN", "secret")
+	_, origin, fix := newPullRequestRepo(t)
+
+	cp := newPullRequestPicker(server.URL)
+	cp.config.PullRequest.BodyTemplate = "{{.Missing}}"
+	err := cp.cherryPickWithConflictHandling([]string{fix})
+	if err == nil || !strings.Contains(err.Error(), "body template") {
+		t.Fatalf("err = %v, want a body template error", err)
+	}
+	if got := git(t, origin, "branch", "--list", "backport/*"); got != "" {
+		t.Errorf("remote has %q, want no backport branch", got)
+	}
+	if len(*requests) != 0 {
+		t.Errorf("got %d forge requests, want none", len(*requests))
+	}
+
+	if err := validatePullRequestTemplates(PullRequestConfig{TitleTemplate: "{{.Title"}); err == nil {
+		t.Error("validatePullRequestTemplates accepted an unclosed action")
+	}
+}
+
+func TestPullRequestFailureStillRunsPostRunHooks(t *testing.T) {
+	server, _ := newStubForge(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`)
+	t.Setenv("TEST_FORGE_TOKEN", "secret")
+	work, origin, fix := newPullRequestRepo(t)
+	releaseBefore := git(t, origin, "rev-parse", "release")
+
+	cp := newPullRequestPicker(server.URL)
+	err := cp.cherryPickWithConflictHandling([]string{fix})
+	if err == nil || !strings.Contains(err.Error(), "failed to open pull request") {
+		t.Fatalf("err = %v, want a pull request failure", err)
+	}
+
+	if got := git(t, origin, "rev-parse", "release"); got != releaseBefore {
+		t.Errorf("remote release moved to %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(work, "post-run-ran")); err != nil {
+		t.Errorf("post_run hook did not run: %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fuzzy scoring, modelled on fzf: every matched character scores, matches at word boundaries
+// and runs of consecutive matches earn bonuses, and gaps between matches cost points
+const (
+	fuzzyScoreMatch       = 16
+	fuzzyBonusBoundary    = 8
+	fuzzyBonusConsecutive = 4
+	fuzzyBonusFirstChar   = 2 // multiplier for the bonus of the pattern's first character
+	fuzzyPenaltyGapStart  = 3
+	fuzzyPenaltyGapExtend = 1
+)
+
+// Highlighting uses attribute-specific resets so it doesn't end the strikethrough or reverse
+// video the commit line may be wrapped in
+const (
+	highlightOn  = "\033[1;33m"
+	highlightOff = "\033[22;39m"
+)
+
+// foldCase lower-cases s rune by rune, like the folding of fuzzyMatch and foldedIndex
+func foldCase(s string) string {
+	return strings.Map(unicode.ToLower, s)
+}
+
+// cacheSearchFields stores the lower-cased fields searched for every keystroke
+func (c *Commit) cacheSearchFields() {
+	c.lowerFull = foldCase(c.Full)
+	c.lowerAuthor = foldCase(c.Author + " " + c.AuthorEmail)
+	c.lowerFiles = make([]string, len(c.FilesChanged))
+	for i, file := range c.FilesChanged {
+		c.lowerFiles[i] = foldCase(file)
+	}
+}
+
+// isFuzzyBoundary reports whether a character starts a word
+func isFuzzyBoundary(prev rune) bool {
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
+
+// fuzzyMatch finds pattern (lower-cased) as a subsequence of text and scores the match. Text
+// is folded rune by rune, so the matched rune positions it returns index text itself; ok is
+// false if pattern isn't a subsequence.
+func fuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+	for i, r := range t {
+		t[i] = unicode.ToLower(r)
+	}
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	// Find the first window containing the pattern, then shrink it from the right end
+	// backwards so e.g. "fix" in "fi fix" matches the word rather than "f", "i", "x"
+	end := -1
+	for i, pi := 0, 0; i < len(t); i++ {
+		if t[i] == p[pi] {
+			pi++
+			if pi == len(p) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	start := end
+	for i, pi := end, len(p)-1; i >= 0; i-- {
+		if t[i] == p[pi] {
+			pi--
+			if pi < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	// Score a forward match inside the window. Characters continuing a run keep the bonus
+	// of the run's first character, so a whole word outscores letters scattered over words.
+	prevMatch := -2
+	chunkBonus := 0
+	for i, pi := start, 0; i <= end && pi < len(p); i++ {
+		if t[i] != p[pi] {
+			continue
+		}
+
+		charScore := fuzzyScoreMatch
+		bonus := 0
+		if i == 0 || isFuzzyBoundary(t[i-1]) {
+			bonus = fuzzyBonusBoundary
+		}
+		if prevMatch == i-1 {
+			bonus = max(bonus, chunkBonus, fuzzyBonusConsecutive)
+		} else {
+			chunkBonus = bonus
+			if prevMatch >= 0 {
+				gap := i - prevMatch - 1
+				charScore -= fuzzyPenaltyGapStart + (gap-1)*fuzzyPenaltyGapExtend
+			}
+		}
+		if pi == 0 {
+			bonus *= fuzzyBonusFirstChar
+		}
+
+		score += charScore + bonus
+		positions = append(positions, i)
+		prevMatch = i
+		pi++
+	}
+	return score, positions, true
+}
+
+// foldedIndex returns the rune position of the first case-insensitive occurrence of pattern
+// (lower-cased) in text, folding text rune by rune, or -1
+func foldedIndex(text, pattern string) int {
+	p := []rune(pattern)
+	t := []rune(text)
+	for i := 0; i+len(p) <= len(t); i++ {
+		j := 0
+		for j < len(p) && unicode.ToLower(t[i+j]) == p[j] {
+			j++
+		}
+		if j == len(p) {
+			return i
+		}
+	}
+	return -1
+}
+
+// runePositions converts a byte range of s to rune positions
+func runePositions(s string, from, to int) []int {
+	var positions []int
+	start := utf8.RuneCountInString(s[:from])
+	for i := range []rune(s[from:to]) {
+		positions = append(positions, start+i)
+	}
+	return positions
+}
+
+// highlightRunes wraps the runes of s at the given positions in highlight codes
+func highlightRunes(s string, positions []int) string {
+	if len(positions) == 0 {
+		return s
+	}
+	marked := make(map[int]bool, len(positions))
+	for _, position := range positions {
+		marked[position] = true
+	}
+
+	var b strings.Builder
+	on := false
+	for i, r := range []rune(s) {
+		if marked[i] != on {
+			on = marked[i]
+			if on {
+				b.WriteString(highlightOn)
+			} else {
+				b.WriteString(highlightOff)
+			}
+		}
+		b.WriteRune(r)
+	}
+	if on {
+		b.WriteString(highlightOff)
+	}
+	return b.String()
+}
+
+// highlightSearchMatches returns the commit line with the message characters matched by the
+// current search highlighted
+func (cp *CherryPicker) highlightSearchMatches(commit Commit) string {
+	positions := cp.searchHighlights[commit.SHA]
+	if !cp.searchActive() || len(positions) == 0 || !strings.HasSuffix(commit.Full, commit.Message) {
+		return commit.Full
+	}
+
+	// Positions are in the message, which ends the "sha message" line
+	offset := utf8.RuneCountInString(commit.Full) - utf8.RuneCountInString(commit.Message)
+	shifted := make([]int, len(positions))
+	for i, position := range positions {
+		shifted[i] = offset + position
+	}
+	return highlightRunes(commit.Full, shifted)
+}
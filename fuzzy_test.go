@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryHighlightsNonASCIIMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		query   string
+		want    []int
+	}{
+		{"fuzzy after a dotted capital I", "İstanbul fix", "fix", []int{9, 10, 11}},
+		{"fuzzy matching the folded rune", "İstanbul fix", "ist", []int{0, 1, 2}},
+		{"phrase after a dotted capital I", "İİİ login fix", `"fix"`, []int{10, 11, 12}},
+		{"phrase at the end of the message", "İİİİ x", `"x"`, []int{5}},
+		{"regex", "Ünïcode Fix", "/Fix/", []int{8, 9, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := parseCommitQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseCommitQuery(%q): %v", tt.query, err)
+			}
+			ok, _, positions := query.Match(Commit{SHA: "a", Message: tt.message, Full: "a " + tt.message})
+			if !ok {
+				t.Fatalf("%q doesn't match %q", tt.query, tt.message)
+			}
+			if !reflect.DeepEqual(positions, tt.want) {
+				t.Errorf("positions = %v, want %v", positions, tt.want)
+			}
+			for _, position := range positions {
+				if position >= len([]rune(tt.message)) {
+					t.Errorf("position %d is past the end of %q", position, tt.message)
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchPrefersWords(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    []int
+	}{
+		{"fix", "fi fix", []int{3, 4, 5}},
+		{"fl", "fix login", []int{0, 4}},
+		{"xyz", "fix", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			_, positions, ok := fuzzyMatch(tt.pattern, tt.text)
+			if ok != (tt.want != nil) || !reflect.DeepEqual(positions, tt.want) {
+				t.Errorf("fuzzyMatch(%q, %q) = %v, %v, want %v", tt.pattern, tt.text, positions, ok, tt.want)
+			}
+		})
+	}
+}
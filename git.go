@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -20,14 +21,18 @@ func (cp *CherryPicker) validateBranch() error {
 		return fmt.Errorf("not on a valid Git branch")
 	}
 
-	// Removed excluded branches check - users can decide where to run the tool
-
 	output, err = exec.Command("git", "config", "user.name").Output()
 	if err != nil {
 		return fmt.Errorf("could not get git user name")
 	}
 	cp.authorName = strings.TrimSpace(string(output))
-	cp.selectedAuthor = cp.authorName // Default to current user
+
+	// Default to the current user, matched by name or email
+	email := ""
+	if output, err := exec.Command("git", "config", "user.email").Output(); err == nil {
+		email = strings.TrimSpace(string(output))
+	}
+	cp.authorFilter = newAuthorFilter(cp.config.Authors, cp.authorName, email)
 
 	return nil
 }
@@ -40,79 +45,69 @@ func (cp *CherryPicker) fetchOrigin() error {
 		return nil
 	}
 
-	// Check if remote exists
-	output, err := exec.Command("git", "remote").Output()
-	if err != nil {
-		fmt.Println("⚠️  No git remotes configured, working with local branches only")
-		return nil
-	}
-
-	remotes := strings.TrimSpace(string(output))
-	if !strings.Contains(remotes, cp.config.Git.Remote) {
-		fmt.Printf("⚠️  No '%s' remote configured, working with local branches only\n", cp.config.Git.Remote)
-		return nil
-	}
-
 	// Try to fetch, but don't fail if it doesn't work
-	if err := exec.Command("git", "fetch", cp.config.Git.Remote).Run(); err != nil {
-		fmt.Printf("⚠️  Could not fetch from %s, working with local branches only\n", cp.config.Git.Remote)
-	}
+	cp.fetchRemotes()
+	cp.fetchNotes()
 
 	return nil
 }
 
 func (cp *CherryPicker) getUniqueCommits() error {
-	// Get all commits from source branch
-	sourceBranch := cp.config.Git.SourceBranch
-	
-	// Try remote branch first, then fall back to local branch
-	remoteSource := cp.config.Git.Remote + "/" + sourceBranch
-	
-	var sourceRef string
-	
-	// Determine source branch reference (remote or local)
-	if err := exec.Command("git", "rev-parse", "--verify", remoteSource).Run(); err == nil {
-		sourceRef = remoteSource
-	} else if err := exec.Command("git", "rev-parse", "--verify", sourceBranch).Run(); err == nil {
-		sourceRef = sourceBranch
-	} else {
-		return fmt.Errorf("source branch '%s' not found", sourceBranch)
+	// Get the source commits that aren't reachable from the target
+	revisions, err := cp.sourceRevisions()
+	if err != nil {
+		return err
 	}
 	
-	// Show all commits in source branch (both applied and not applied to target)
-	// We'll check individually which ones are already applied
-	cmd := exec.Command("git", "log", sourceRef, "--author="+cp.selectedAuthor, "--oneline")
+	// Cherry-picked copies are still listed; they are marked below and by annotateBackports
+	// Authors are resolved through .mailmap and filtered below
+	args := append([]string{"log", "--format=%h%x09%aN%x09%aE%x09%s"}, revisions...)
+	args = append(args, cp.pathArgs()...)
+	cmd := exec.Command("git", args...)
 
 	output, err := cmd.Output()
 	if err != nil {
 		return fmt.Errorf("failed to get unique commits: %v", err)
 	}
 
+	ticketPattern, _ := compileTicketPattern(cp.config.Tickets.Pattern)
+
 	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
 	for _, line := range lines {
 		if line == "" {
 			continue
 		}
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) >= 2 {
+		parts := strings.SplitN(line, "\t", 4)
+		if len(parts) >= 4 {
 			sha := parts[0]
-			message := parts[1]
+			message := parts[3]
+			if !cp.authorFilter.matches(parts[1], parts[2], cp.config.Authors.Teams) {
+				continue
+			}
+			full := sha + " " + message
 			
 			// Get detailed commit information
-			commit, err := cp.getCommitDetails(sha, message, line)
+			commit, err := cp.getCommitDetails(sha, message, full)
 			if err != nil {
 				// Fallback to basic commit info if detailed fetch fails
 				commit = Commit{
 					SHA:     sha,
 					Message: message,
-					Full:    line,
+					Full:    full,
+					Author:  parts[1],
 				}
 			}
+			commit.AuthorEmail = parts[2]
+			commit.cacheSearchFields()
 			
 			// Quick check if commit exists in target branch (simple ancestor check)
-			// Note: This should rarely be true since git log targetRef..sourceRef
-			// already filters out commits that are in target
+			// Note: This should rarely be true since the revisions already
+			// exclude commits reachable from the target
 			commit.AlreadyApplied = cp.quickCheckAlreadyApplied(sha)
+			commit.Tickets = commitTickets(ticketPattern, commit)
+			if cc, ok := parseConventionalCommit(message, commit.Body); ok {
+				commit.Conventional = &cc
+			}
 			
 			cp.commits = append(cp.commits, commit)
 		}
@@ -128,6 +123,9 @@ func (cp *CherryPicker) getUniqueCommits() error {
 	}
 	// If cp.reverse is true, keep git's natural order (newest first)
 
+	cp.annotateBackports()
+	cp.annotatePolicyViolations()
+
 	// Always start cursor at the top
 	cp.currentIndex = 0
 
@@ -142,13 +140,19 @@ func (cp *CherryPicker) getCommitDetails(sha, message, full string) (Commit, err
 		Full:    full,
 	}
 
-	// Get commit date and author
-	output, err := exec.Command("git", "show", "--format=%ai|%an|%P", "--name-only", sha).Output()
+	// Get commit date, author and body; a NUL separates the body from the file list
+	output, err := exec.Command("git", "show", "--format=%ai|%aN|%P%n%b%x00", "--name-only", sha).Output()
 	if err != nil {
 		return commit, err
 	}
 
-	lines := strings.Split(string(output), "\n")
+	header, files, _ := strings.Cut(string(output), "\x00")
+	headerLines := strings.SplitN(header, "\n", 2)
+	if len(headerLines) > 1 {
+		commit.Body = strings.TrimSpace(headerLines[1])
+	}
+
+	lines := append([]string{headerLines[0]}, strings.Split(files, "\n")...)
 	if len(lines) < 1 {
 		return commit, fmt.Errorf("invalid git show output")
 	}
@@ -177,6 +181,18 @@ func (cp *CherryPicker) getCommitDetails(sha, message, full string) (Commit, err
 			commit.FilesChanged = append(commit.FilesChanged, lines[i])
 		}
 	}
+	
+	// Remember which of them are inside the path scope for the detail view
+	if len(cp.paths) > 0 {
+		scoped, err := exec.Command("git", append([]string{"show", "--name-only", "--format=", sha}, cp.pathArgs()...)...).Output()
+		if err == nil {
+			for _, file := range strings.Split(string(scoped), "\n") {
+				if strings.TrimSpace(file) != "" {
+					commit.ScopedFiles = append(commit.ScopedFiles, file)
+				}
+			}
+		}
+	}
 
 	// Get stats (insertions/deletions)
 	statsOutput, err := exec.Command("git", "show", "--stat", "--format=", sha).Output()
@@ -187,6 +203,15 @@ func (cp *CherryPicker) getCommitDetails(sha, message, full string) (Commit, err
 	return commit, nil
 }
 
+// getCommitSubject returns the subject line of a commit, or an empty string if unknown
+func getCommitSubject(sha string) string {
+	output, err := exec.Command("git", "log", "-1", "--format=%s", sha).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 // parseGitStats parses git show --stat output to extract insertions and deletions
 func (cp *CherryPicker) parseGitStats(statsOutput string) (int, int) {
 	lines := strings.Split(statsOutput, "\n")
@@ -228,8 +253,23 @@ func (cp *CherryPicker) parseGitStats(statsOutput string) (int, int) {
 
 // cherryPickWithConflictHandling performs cherry-pick with conflict resolution
 func (cp *CherryPicker) cherryPickWithConflictHandling(shas []string) error {
+	if err := cp.requireProtectedConfirmation(); err != nil {
+		return err
+	}
+	if err := cp.enforcePolicies(shas); err != nil {
+		return err
+	}
+	
+	for _, warning := range cp.policyWarnings() {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+	
 	targetBranch := cp.config.Git.TargetBranch
-	remote := cp.config.Git.Remote
+	remote := cp.targetRemote()
+	
+	if err := cp.runHooks(cp.config.Hooks.PreRun, cp.newHookEvent(hookPreRun, shas)); err != nil {
+		return err
+	}
 	
 	fmt.Printf("🔀 Switching to %s...\n", targetBranch)
 	if err := exec.Command("git", "checkout", targetBranch).Run(); err != nil {
@@ -238,8 +278,7 @@ func (cp *CherryPicker) cherryPickWithConflictHandling(shas []string) error {
 
 	if cp.config.Git.AutoFetch {
 		// Check if remote exists before trying to pull
-		output, err := exec.Command("git", "remote").Output()
-		if err == nil && strings.Contains(strings.TrimSpace(string(output)), remote) {
+		if hasRemote(remote) {
 			// Remote exists, try to pull
 			if err := exec.Command("git", "pull", remote, targetBranch).Run(); err != nil {
 				fmt.Printf("⚠️  Could not pull from %s, continuing with local branch\n", remote)
@@ -248,85 +287,219 @@ func (cp *CherryPicker) cherryPickWithConflictHandling(shas []string) error {
 			fmt.Printf("⚠️  No '%s' remote configured, using local branch only\n", remote)
 		}
 	}
+	
+	// Remember the remote tip so the push can detect that it moved
+	cp.recordRemoteHead()
+	
+	// Pull request runs pick onto a backport branch cut from the target
+	cp.backportBranch = ""
+	cp.pullRequestURL = ""
+	if cp.config.PullRequest.Enabled {
+		branch := cp.backportBranchName(cp.commitsForSHAs(shas))
+		fmt.Printf("🌱 Creating %s from %s...\n", branch, targetBranch)
+		if err := exec.Command("git", "checkout", "-B", branch).Run(); err != nil {
+			return fmt.Errorf("failed to create %s: %v", branch, err)
+		}
+		cp.backportBranch = branch
+	}
+	
+	// Remember where the run started so it can be rolled back
+	if output, err := exec.Command("git", "rev-parse", "HEAD").Output(); err == nil {
+		cp.runStartHead = strings.TrimSpace(string(output))
+	}
+	cp.runSHAs = shas
+	cp.runPosition = 0
+	cp.pickedSHAs = nil
+	cp.pickedTargets = make(map[string]string)
+	cp.pickBases = make(map[string]string)
+	cp.runEntries = nil
+	cp.runPushed = false
+	cp.finalVerifyDone = false
 
 	fmt.Println("🍒 Cherry-picking selected commits...")
+	return cp.pickCommits()
+}
+
+// pickCommits cherry-picks the remaining commits of the run one by one
+func (cp *CherryPicker) pickCommits() error {
+	hooks := cp.config.Hooks
+	shas := cp.runSHAs
 	
 	// Cherry-pick commits one by one to handle conflicts individually
-	for i, sha := range shas {
+	for cp.runPosition < len(shas) {
+		i := cp.runPosition
+		sha := shas[i]
+		cp.runPosition++
+		
 		shaDisplay := sha
 		if len(sha) > 8 {
 			shaDisplay = sha[:8]
 		}
+		
+		event := cp.newHookEvent(hookPreCommitPick, shas)
+		event.SHA = sha
+		event.Index = i + 1
+		if err := cp.runHooks(hooks.PreCommitPick, event); err != nil {
+			if hooks.OnFailure == "skip" {
+				fmt.Printf("⏭️  Skipping %s: %v\n", shaDisplay, err)
+				cp.ledgerRecord(LedgerEntry{SourceSHA: sha, Resolution: "skipped by pre_commit_pick hook"})
+				continue
+			}
+			return err
+		}
+		
 		fmt.Printf("Cherry-picking %s (%d/%d)...\n", shaDisplay, i+1, len(shas))
 		
+		// Remember where this pick started so a revert drops exactly it and whatever
+		// the post_commit_pick hooks added after it
+		if output, err := exec.Command("git", "rev-parse", "HEAD").Output(); err == nil {
+			cp.pickBases[sha] = strings.TrimSpace(string(output))
+		}
+		
 		err := exec.Command("git", "cherry-pick", sha).Run()
 		if err != nil {
 			// Check if it's a conflict
 			if cp.hasConflicts() {
 				fmt.Printf("⚠️  Conflict detected in commit %s\n", sha)
+				event.Hook = hookOnConflict
+				cp.runPostHooks(hooks.OnConflict, event)
 				cp.enterConflictMode(sha)
 				// Return a special conflict error that main.go can handle
 				return fmt.Errorf("CONFLICT_DETECTED:%s", sha)
 			}
 			return fmt.Errorf("cherry-pick failed for %s: %v", sha, err)
 		}
+		
+		cp.pickedSHAs = append(cp.pickedSHAs, sha)
+		cp.recordPick(sha, false, "")
+		event.Hook = hookPostCommitPick
+		cp.runPostHooks(hooks.PostCommitPick, event)
+		
+		if cp.config.Behavior.VerifyMode != "end" {
+			if err := cp.verifyPick(sha); err != nil {
+				return err
+			}
+		}
+	}
+	
+	if cp.config.Behavior.VerifyMode == "end" && !cp.finalVerifyDone {
+		cp.finalVerifyDone = true
+		if err := cp.verifyPick(""); err != nil {
+			return err
+		}
+	}
+	
+	return cp.finishRun()
+}
+
+// finishRun wraps up once every commit of the run has been handled
+func (cp *CherryPicker) finishRun() error {
+	picked := cp.pickedSHAs
+	
+	if len(picked) == 0 {
+		cp.reportRun()
+		return fmt.Errorf("no commits were cherry-picked")
 	}
 
 	fmt.Println("✅ Cherry-pick successful.")
 	
-	if cp.config.Behavior.AutoPush {
-		fmt.Printf("🚀 Pushing to %s...\n", remote)
-		if err := exec.Command("git", "push", remote, targetBranch).Run(); err != nil {
-			return fmt.Errorf("failed to push: %v", err)
+	// Add the changelog commit before pushing so it goes out with the picks
+	if cp.config.Changelog.Enabled {
+		if err := cp.updateChangelog(picked); err != nil {
+			fmt.Printf("⚠️  Could not update changelog: %v\n", err)
+		}
+	}
+	
+	cp.pushConfirmed = false
+	cp.skipPush = false
+	cp.forcePush = false
+	cp.forceConfirmed = false
+	return cp.publishRun()
+}
+
+// publishRun pushes the target, or the backport branch of a pull request run, and
+// reports. With push previews enabled it first returns PUSH_PREVIEW so main.go can
+// show the commits about to be pushed. A failed pull request still reports the run.
+func (cp *CherryPicker) publishRun() error {
+	if cp.backportBranch != "" {
+		return cp.publishPullRequest()
+	}
+	
+	targetBranch := cp.config.Git.TargetBranch
+	autoPush := cp.config.Behavior.AutoPush && !cp.skipPush
+	
+	if autoPush && cp.isProtectedTarget() {
+		fmt.Printf("🔒 %s is protected; auto-push is blocked. Review and push manually.\n", targetBranch)
+	} else if autoPush {
+		if cp.config.Behavior.PreviewPush && !cp.pushConfirmed {
+			cp.enterPushPreviewMode()
+			return fmt.Errorf("PUSH_PREVIEW")
+		}
+		if err := cp.pushTarget(); err != nil {
+			return err
 		}
-		fmt.Println("✅ Pushed successfully.")
+		cp.ledgerMarkPushed()
+		cp.pushNotes()
 	} else {
 		fmt.Printf("🛑 Cherry-picked to %s but not pushed. Review and push manually.\n", targetBranch)
 	}
-	
+
 	fmt.Println()
 	fmt.Println("📣 Now you can open a merge request when ready.")
-
+	
+	cp.reportRun()
 	return nil
 }
 
-// getAvailableAuthors gets all authors who have committed to the source branch
-func (cp *CherryPicker) getAvailableAuthors() error {
-	sourceBranch := cp.config.Git.SourceBranch
+// publishPullRequest pushes the backport branch and opens a pull/merge request for it,
+// leaving the target branch untouched
+func (cp *CherryPicker) publishPullRequest() error {
+	if cp.config.Behavior.PreviewPush && !cp.pushConfirmed {
+		cp.enterPushPreviewMode()
+		return fmt.Errorf("PUSH_PREVIEW")
+	}
 	
-	// Try remote branch first, then fall back to local branch
-	remoteSource := cp.config.Git.Remote + "/" + sourceBranch
-	var sourceRef string
+	var err error
+	if cp.skipPush {
+		fmt.Printf("🛑 Cherry-picked to %s but not pushed. Review and open a pull request manually.\n", cp.backportBranch)
+	} else if err = cp.openPullRequest(cp.pickedSHAs); err == nil {
+		cp.ledgerMarkPushed()
+	}
 	
-	if err := exec.Command("git", "rev-parse", "--verify", remoteSource).Run(); err == nil {
-		sourceRef = remoteSource
-	} else if err := exec.Command("git", "rev-parse", "--verify", sourceBranch).Run(); err == nil {
-		sourceRef = sourceBranch
-	} else {
-		return fmt.Errorf("source branch '%s' not found", sourceBranch)
+	cp.reportRun()
+	return err
+}
+
+// reportRun writes the run report and runs the post_run hooks
+func (cp *CherryPicker) reportRun() {
+	if cp.config.Report.OnRun {
+		cp.writeRunReport()
 	}
 	
-	// Get all authors from the source branch
-	cmd := exec.Command("git", "log", sourceRef, "--format=%an", "--pretty=format:%an")
-	output, err := cmd.Output()
+	cp.runPostHooks(cp.config.Hooks.PostRun, cp.newHookEvent(hookPostRun, cp.pickedSHAs))
+}
+
+// pickBranch returns the branch the run picks onto
+func (cp *CherryPicker) pickBranch() string {
+	if cp.backportBranch != "" {
+		return cp.backportBranch
+	}
+	return cp.config.Git.TargetBranch
+}
+
+// getAvailableAuthors gets the authors of the source commits not yet in the target
+func (cp *CherryPicker) getAvailableAuthors() error {
+	revisions, err := cp.sourceRevisions()
 	if err != nil {
-		return fmt.Errorf("failed to get authors: %v", err)
+		return err
 	}
 	
-	// Parse authors and remove duplicates
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	authorSet := make(map[string]bool)
-	var authors []string
-	
-	for _, line := range lines {
-		author := strings.TrimSpace(line)
-		if author != "" && !authorSet[author] {
-			authorSet[author] = true
-			authors = append(authors, author)
-		}
+	authors, err := listAuthorIdentities(append(revisions, cp.pathArgs()...))
+	if err != nil {
+		return err
 	}
 	
-	cp.availableAuthors = authors
+	cp.availableAuthors = cp.authorOptions(authors)
 	return nil
 }
 
@@ -478,6 +651,49 @@ func (cp *CherryPicker) continueConflictResolution() error {
 	return exec.Command("git", "cherry-pick", "--continue").Run()
 }
 
+// applyConflictDecision records how the conflict of the run was handled and resumes the
+// run with the next commit, or ends it. A run that ends here is still reported and
+// runs the post_run hooks.
+func (cp *CherryPicker) applyConflictDecision() error {
+	decision := cp.conflictDecision
+	sha := cp.conflictCommit
+	cp.exitConflictMode()
+	cp.conflictDecision = ""
+
+	shaDisplay := sha
+	if len(sha) > 8 {
+		shaDisplay = sha[:8]
+	}
+
+	switch decision {
+	case "continue":
+		cp.pickedSHAs = append(cp.pickedSHAs, sha)
+		cp.recordPick(sha, true, "resolved manually")
+		event := cp.newHookEvent(hookPostCommitPick, cp.runSHAs)
+		event.SHA = sha
+		event.Index = cp.runPosition
+		cp.runPostHooks(cp.config.Hooks.PostCommitPick, event)
+
+		// A hand resolution is verified like any other pick before the run goes on
+		if cp.config.Behavior.VerifyMode != "end" {
+			if err := cp.verifyPick(sha); err != nil {
+				return err
+			}
+		}
+		return cp.pickCommits()
+	case "skip":
+		cp.ledgerRecord(LedgerEntry{SourceSHA: sha, Conflict: true, Resolution: "skipped"})
+		return cp.pickCommits()
+	case "abort":
+		cp.ledgerRecord(LedgerEntry{SourceSHA: sha, Conflict: true, Resolution: "aborted"})
+		cp.reportRun()
+		return fmt.Errorf("run aborted at the conflict in %s; earlier picks are left unpushed on %s", shaDisplay, cp.pickBranch())
+	default:
+		cp.reportRun()
+		return fmt.Errorf("run stopped with the cherry-pick of %s in progress; finish it with git cherry-pick --continue or --abort", shaDisplay)
+	}
+}
+
 // abortConflictResolution aborts the current cherry-pick
 func (cp *CherryPicker) abortConflictResolution() error {
 	return exec.Command("git", "cherry-pick", "--abort").Run()
@@ -611,69 +827,28 @@ func (cp *CherryPicker) openAllConflictedFiles(editorOption EditorOption) error
 	return nil
 }
 
-// getAvailableBranches returns a list of available branches for switching
-func (cp *CherryPicker) getAvailableBranches() ([]string, error) {
-	var branches []string
+// getAvailableBranches returns the branches available for switching, from the remote of the
+// branch being switched, excluding the current branch
+func (cp *CherryPicker) getAvailableBranches() ([]BranchInfo, error) {
+	remote := cp.targetRemote()
+	if cp.branchSwitchType == "source" {
+		remote = cp.sourceRemote()
+	}
 	
-	// Get local branches
-	localOutput, err := exec.Command("git", "branch", "--format=%(refname:short)").Output()
+	listed, err := listBranches(remote, cp.branchScope, cp.config.Git.HiddenBranches)
 	if err != nil {
 		return nil, err
 	}
 	
-	localBranches := strings.Split(strings.TrimSpace(string(localOutput)), "\n")
-	for _, branch := range localBranches {
-		branch = strings.TrimSpace(branch)
-		if branch != "" && branch != cp.currentBranch {
+	var branches []BranchInfo
+	for _, branch := range listed {
+		if branch.Name != cp.currentBranch {
 			branches = append(branches, branch)
 		}
 	}
-	
-	// Get remote branches if remote exists
-	if output, err := exec.Command("git", "remote").Output(); err == nil {
-		remotes := strings.TrimSpace(string(output))
-		if strings.Contains(remotes, cp.config.Git.Remote) {
-			remoteOutput, err := exec.Command("git", "branch", "-r", "--format=%(refname:short)").Output()
-			if err == nil {
-				remoteBranches := strings.Split(strings.TrimSpace(string(remoteOutput)), "\n")
-				for _, branch := range remoteBranches {
-					branch = strings.TrimSpace(branch)
-					if branch != "" && !strings.Contains(branch, "HEAD") {
-						// Add remote branches, removing remote prefix for display
-						if strings.HasPrefix(branch, cp.config.Git.Remote+"/") {
-							localName := strings.TrimPrefix(branch, cp.config.Git.Remote+"/")
-							// Only add if we don't already have this local branch
-							found := false
-							for _, existing := range branches {
-								if existing == localName {
-									found = true
-									break
-								}
-							}
-							if !found && localName != cp.currentBranch {
-								branches = append(branches, localName)
-							}
-						}
-					}
-				}
-			}
-		}
-	}
-	
 	return branches, nil
 }
 
-// resolveConflicts provides options for conflict resolution
-func (cp *CherryPicker) resolveConflicts() error {
-	fmt.Println("🔧 Conflict resolution options:")
-	fmt.Println("1. Open merge tool: git mergetool")
-	fmt.Println("2. Skip this commit: git cherry-pick --skip")
-	fmt.Println("3. Abort cherry-pick: git cherry-pick --abort")
-	fmt.Println("4. Continue after manual resolution: git cherry-pick --continue")
-	
-	return nil
-}
-
 // interactiveRebase launches interactive rebase for selected commits
 func (cp *CherryPicker) interactiveRebase(shas []string) error {
 	if len(shas) == 0 {
@@ -713,19 +888,10 @@ func (cp *CherryPicker) interactiveRebase(shas []string) error {
 // This checks both for exact SHA matches and for cherry-picked commits with same content
 func (cp *CherryPicker) isCommitInTargetBranch(sha string) bool {
 	targetBranch := cp.config.Git.TargetBranch
-	remote := cp.config.Git.Remote
 	
 	// Try remote/target branch first, then fall back to local target branch
-	var remoteBranch, localBranch string
-	
-	// Check if remote exists
-	if output, err := exec.Command("git", "remote").Output(); err == nil {
-		remotes := strings.TrimSpace(string(output))
-		if strings.Contains(remotes, remote) {
-			remoteBranch = remote + "/" + targetBranch
-		}
-	}
-	localBranch = targetBranch
+	remoteBranch := remoteBranchRef(cp.targetRemote(), targetBranch)
+	localBranch := targetBranch
 	
 	// First try to check against remote target branch
 	if remoteBranch != "" {
@@ -866,20 +1032,27 @@ func (cp *CherryPicker) normalizePatch(patch string) string {
 	return strings.Join(normalizedLines, "\n")
 }
 
+// resolveTargetRef returns the remote target branch if it exists, else the local one,
+// or an empty string if neither exists
+func (cp *CherryPicker) resolveTargetRef() string {
+	return resolveBranchRef(cp.targetRemote(), cp.config.Git.TargetBranch)
+}
+
+// matchesBranchPattern reports whether a branch matches any of the glob patterns, e.g. "hotfix/*"
+func matchesBranchPattern(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, branch); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 // quickCheckAlreadyApplied does a fast ancestor check to see if commit exists in target
 func (cp *CherryPicker) quickCheckAlreadyApplied(sha string) bool {
-	targetBranch := cp.config.Git.TargetBranch
-	remote := cp.config.Git.Remote
-	
 	// Try remote target branch first, then local
-	var targetRef string
-	remoteTarget := remote + "/" + targetBranch
-	
-	if err := exec.Command("git", "rev-parse", "--verify", remoteTarget).Run(); err == nil {
-		targetRef = remoteTarget
-	} else if err := exec.Command("git", "rev-parse", "--verify", targetBranch).Run(); err == nil {
-		targetRef = targetBranch
-	} else {
+	targetRef := cp.resolveTargetRef()
+	if targetRef == "" {
 		// Target branch not found, assume not applied
 		return false
 	}
@@ -891,23 +1064,35 @@ func (cp *CherryPicker) quickCheckAlreadyApplied(sha string) bool {
 
 // getCommitDiff returns the full diff for a commit
 func (cp *CherryPicker) getCommitDiff(sha string) (string, error) {
-	output, err := exec.Command("git", "show", "--format=fuller", "--stat", "--patch", sha).Output()
+	args := append([]string{"show", "--format=fuller", "--stat", "--patch", sha}, cp.pathArgs()...)
+	output, err := exec.Command("git", args...).Output()
 	if err != nil {
 		return "", err
 	}
 	return string(output), nil
 }
 
-// getCommitStats returns detailed statistics for a commit
+// getCommitStats returns detailed statistics for a commit, covering every file it changed
 func (cp *CherryPicker) getCommitStats(sha string) (string, error) {
+	return cp.commitStats(sha, nil)
+}
+
+// getScopedCommitStats returns the statistics of a commit shown in the preview, limited to
+// the path scope
+func (cp *CherryPicker) getScopedCommitStats(sha string) (string, error) {
+	return cp.commitStats(sha, cp.pathArgs())
+}
+
+// commitStats returns the statistics of a commit, limited by the given pathspec arguments
+func (cp *CherryPicker) commitStats(sha string, pathArgs []string) (string, error) {
 	// Get numstat (numerical stats)
-	numstatOutput, err := exec.Command("git", "show", "--numstat", "--format=", sha).Output()
+	numstatOutput, err := exec.Command("git", append([]string{"show", "--numstat", "--format=", sha}, pathArgs...)...).Output()
 	if err != nil {
 		return "", err
 	}
 	
 	// Get shortstat (summary)
-	shortstatOutput, err := exec.Command("git", "show", "--shortstat", "--format=", sha).Output()
+	shortstatOutput, err := exec.Command("git", append([]string{"show", "--shortstat", "--format=", sha}, pathArgs...)...).Output()
 	if err != nil {
 		return "", err
 	}
@@ -919,6 +1104,9 @@ func (cp *CherryPicker) getCommitStats(sha string) (string, error) {
 	if shortstat != "" {
 		stats.WriteString("📊 Summary: " + shortstat + "\n\n")
 	}
+	if len(pathArgs) > 0 {
+		stats.WriteString(cp.pathStats(sha))
+	}
 	
 	// Parse and display detailed file stats
 	numstatLines := strings.Split(strings.TrimSpace(string(numstatOutput)), "\n")
@@ -949,49 +1137,3 @@ func (cp *CherryPicker) getCommitStats(sha string) (string, error) {
 	
 	return stats.String(), nil
 }
-
-func (cp *CherryPicker) cherryPick(shas []string) error {
-	targetBranch := cp.config.Git.TargetBranch
-	remote := cp.config.Git.Remote
-	
-	fmt.Printf("🔀 Switching to %s...\n", targetBranch)
-	if err := exec.Command("git", "checkout", targetBranch).Run(); err != nil {
-		return fmt.Errorf("failed to checkout %s: %v", targetBranch, err)
-	}
-
-	if cp.config.Git.AutoFetch {
-		// Check if remote exists before trying to pull
-		output, err := exec.Command("git", "remote").Output()
-		if err == nil && strings.Contains(strings.TrimSpace(string(output)), remote) {
-			// Remote exists, try to pull
-			if err := exec.Command("git", "pull", remote, targetBranch).Run(); err != nil {
-				fmt.Printf("⚠️  Could not pull from %s, continuing with local branch\n", remote)
-			}
-		} else {
-			fmt.Printf("⚠️  No '%s' remote configured, using local branch only\n", remote)
-		}
-	}
-
-	fmt.Println("🍒 Cherry-picking selected commits...")
-	args := append([]string{"cherry-pick"}, shas...)
-	if err := exec.Command("git", args...).Run(); err != nil {
-		return fmt.Errorf("cherry-pick failed: %v", err)
-	}
-
-	fmt.Println("✅ Cherry-pick successful.")
-	
-	if cp.config.Behavior.AutoPush {
-		fmt.Printf("🚀 Pushing to %s...\n", remote)
-		if err := exec.Command("git", "push", remote, targetBranch).Run(); err != nil {
-			return fmt.Errorf("failed to push: %v", err)
-		}
-		fmt.Println("✅ Pushed successfully.")
-	} else {
-		fmt.Printf("🛑 Cherry-picked to %s but not pushed. Review and push manually.\n", targetBranch)
-	}
-	
-	fmt.Println()
-	fmt.Println("📣 Now you can open a merge request when ready.")
-
-	return nil
-}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newConflictRun starts a run of two commits whose first conflicts with release, and
+// returns it stopped at the conflict
+func newConflictRun(t *testing.T) (cp *CherryPicker, work, origin string) {
+	t.Helper()
+	t.Setenv("GIT_EDITOR", "true")
+	work, origin, fix := newPullRequestRepo(t)
+
+	os.WriteFile(filepath.Join(work, "new.txt"), []byte("new\n"), 0644)
+	git(t, work, "add", ".")
+	git(t, work, "commit", "--quiet", "-m", "Add a file")
+	clean := git(t, work, "rev-parse", "HEAD")
+
+	git(t, work, "checkout", "--quiet", "release")
+	os.WriteFile(filepath.Join(work, "app.txt"), []byte("three\n"), 0644)
+	git(t, work, "commit", "--quiet", "-am", "Change the thing on release")
+	git(t, work, "push", "--quiet", "origin", "release")
+	git(t, work, "checkout", "--quiet", "main")
+
+	cp = newPushPicker()
+	cp.config.Hooks.PostRun = []string{"touch post-run-ran"}
+	err := cp.cherryPickWithConflictHandling([]string{fix, clean})
+	if err == nil || !strings.Contains(err.Error(), "CONFLICT_DETECTED") {
+		t.Fatalf("err = %v, want a conflict", err)
+	}
+	return cp, work, origin
+}
+
+func TestConflictResolutionResumesTheRun(t *testing.T) {
+	cp, work, origin := newConflictRun(t)
+
+	os.WriteFile(filepath.Join(work, "app.txt"), []byte("resolved\n"), 0644)
+	git(t, work, "add", "app.txt")
+	if err := cp.continueConflictResolution(); err != nil {
+		t.Fatalf("continue: %v", err)
+	}
+	cp.conflictDecision = "continue"
+	if err := cp.applyConflictDecision(); err != nil {
+		t.Fatalf("resumed run failed: %v", err)
+	}
+
+	if len(cp.pickedSHAs) != 2 {
+		t.Errorf("picked %d commits, want both", len(cp.pickedSHAs))
+	}
+	if got := git(t, origin, "log", "--format=%s", "-2", "release"); got != "Add a file\nFix the thing" {
+		t.Errorf("remote release ends with %q, want both picks pushed", got)
+	}
+	if _, err := os.Stat(filepath.Join(work, "post-run-ran")); err != nil {
+		t.Errorf("post_run hook did not run: %v", err)
+	}
+}
+
+func TestConflictResolutionIsVerified(t *testing.T) {
+	cp, work, origin := newConflictRun(t)
+	releaseBefore := git(t, origin, "rev-parse", "release")
+	cp.config.Behavior.VerifyCommand = "! grep -q broken app.txt"
+
+	os.WriteFile(filepath.Join(work, "app.txt"), []byte("broken\n"), 0644)
+	git(t, work, "add", "app.txt")
+	if err := cp.continueConflictResolution(); err != nil {
+		t.Fatalf("continue: %v", err)
+	}
+	cp.conflictDecision = "continue"
+	err := cp.applyConflictDecision()
+	if err == nil || !strings.Contains(err.Error(), "VERIFY_FAILED") {
+		t.Fatalf("err = %v, want the resolution to fail verification", err)
+	}
+	if got := git(t, origin, "rev-parse", "release"); got != releaseBefore {
+		t.Errorf("remote release moved to %s before the failed verification was handled", got)
+	}
+
+	// Reverting the resolved pick goes on with the next commit
+	cp.verifyDecision = "revert"
+	if err := cp.applyVerifyDecision(); err != nil {
+		t.Fatalf("run after revert failed: %v", err)
+	}
+	if got := git(t, origin, "log", "--format=%s", "-1", "release"); got != "Add a file" {
+		t.Errorf("remote release ends with %q, want only the clean pick pushed", got)
+	}
+}
+
+func TestConflictAbortStillRunsPostRunHooks(t *testing.T) {
+	cp, work, _ := newConflictRun(t)
+
+	if err := cp.abortConflictResolution(); err != nil {
+		t.Fatalf("abort: %v", err)
+	}
+	cp.conflictDecision = "abort"
+	if err := cp.applyConflictDecision(); err == nil || !strings.Contains(err.Error(), "aborted") {
+		t.Fatalf("err = %v, want the run aborted", err)
+	}
+	if _, err := os.Stat(filepath.Join(work, "post-run-ran")); err != nil {
+		t.Errorf("post_run hook did not run: %v", err)
+	}
+}
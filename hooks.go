@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// Hook names, passed to scripts as CHERRY_PICKER_HOOK
+const (
+	hookPreRun         = "pre_run"
+	hookPreCommitPick  = "pre_commit_pick"
+	hookPostCommitPick = "post_commit_pick"
+	hookOnConflict     = "on_conflict"
+	hookPostRun        = "post_run"
+)
+
+// HookEvent is the context handed to hook scripts as JSON on stdin
+type HookEvent struct {
+	Hook    string   `json:"hook"`
+	Session string   `json:"session"`
+	Source  string   `json:"source"`
+	Target  string   `json:"target"`
+	Remote  string   `json:"remote"`
+	SHA     string   `json:"sha,omitempty"`
+	Index   int      `json:"index,omitempty"`
+	Total   int      `json:"total"`
+	SHAs    []string `json:"shas"`
+}
+
+// session returns the identifier of the current run, creating it on first use
+func (cp *CherryPicker) session() string {
+	if cp.sessionID == "" {
+		cp.sessionID = time.Now().Format("20060102-150405")
+	}
+	return cp.sessionID
+}
+
+// newHookEvent builds the event for a hook, filling in session details
+func (cp *CherryPicker) newHookEvent(hook string, shas []string) HookEvent {
+	return HookEvent{
+		Hook:    hook,
+		Session: cp.session(),
+		Source:  cp.config.Git.SourceBranch,
+		Target:  cp.config.Git.TargetBranch,
+		Remote:  cp.targetRemote(),
+		Total:   len(shas),
+		SHAs:    shas,
+	}
+}
+
+// runHooks runs each command for a hook, stopping at the first failure
+func (cp *CherryPicker) runHooks(commands []string, event HookEvent) error {
+	if len(commands) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook event: %v", err)
+	}
+
+	env := append(os.Environ(),
+		"CHERRY_PICKER_HOOK="+event.Hook,
+		"CHERRY_PICKER_SESSION="+event.Session,
+		"CHERRY_PICKER_SOURCE="+event.Source,
+		"CHERRY_PICKER_TARGET="+event.Target,
+		"CHERRY_PICKER_REMOTE="+event.Remote,
+		"CHERRY_PICKER_SHA="+event.SHA,
+		"CHERRY_PICKER_INDEX="+strconv.Itoa(event.Index),
+		"CHERRY_PICKER_TOTAL="+strconv.Itoa(event.Total),
+	)
+
+	for _, command := range commands {
+		fmt.Printf("🪝 Running %s hook: %s\n", event.Hook, command)
+
+		cmd := shellCommand(command)
+		cmd.Env = env
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s hook %q failed: %v", event.Hook, command, err)
+		}
+	}
+	return nil
+}
+
+// runPostHooks runs hooks whose failure should only be reported, not stop the run
+func (cp *CherryPicker) runPostHooks(commands []string, event HookEvent) {
+	if err := cp.runHooks(commands, event); err != nil {
+		fmt.Printf("⚠️  %v\n", err)
+	}
+}
+
+// shellCommand wraps a command line in the platform shell
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
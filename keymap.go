@@ -0,0 +1,716 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Key modes: each screen of the TUI has its own bindings
+const (
+	keyModeList         = "list"
+	keyModeSearch       = "search"
+	keyModePreview      = "preview"
+	keyModeDiff         = "diff"
+	keyModeConflict     = "conflict"
+	keyModeEditor       = "editor"
+	keyModeBranch       = "branch"
+	keyModeAuthor       = "author"
+	keyModeAuthorSearch = "author_search"
+	keyModeTypeFilter   = "type_filter"
+	keyModePaths        = "paths"
+	keyModePickaxe      = "diff_search"
+	keyModeProtect      = "protect_confirm"
+	keyModePushPreview  = "push_preview"
+	keyModeVerify       = "verify"
+	keyModeStartBranch  = "start_branch"
+	keyModeStartSearch  = "start_branch_search"
+	keyModeHelp         = "help"
+)
+
+// keyModeTitles names the modes in the help overlay
+var keyModeTitles = map[string]string{
+	keyModeList:         "Commit List",
+	keyModeSearch:       "Search",
+	keyModePreview:      "Commit Preview",
+	keyModeDiff:         "Diff Viewer",
+	keyModeConflict:     "Conflict Resolution",
+	keyModeEditor:       "Editor Selection",
+	keyModeBranch:       "Branch Selection",
+	keyModeAuthor:       "Author Selection",
+	keyModeAuthorSearch: "Author Search",
+	keyModeTypeFilter:   "Type Filter",
+	keyModePaths:        "Path Scope",
+	keyModePickaxe:      "Diff Search",
+	keyModeProtect:      "Protected Target Confirmation",
+	keyModePushPreview:  "Push Preview",
+	keyModeVerify:       "Failed Verification",
+	keyModeStartBranch:  "Startup Branch Selection",
+	keyModeStartSearch:  "Startup Branch Search",
+	keyModeHelp:         "Help",
+}
+
+// keyBinding binds keys to a named action of a mode. The mode's input handler switches on the
+// action, and the help overlay and controls lines are generated from the same bindings.
+type keyBinding struct {
+	Action string
+	Keys   []string // as tea.KeyMsg.String() reports them
+	Help   string
+	Group  string // heading the help overlay lists the binding under
+	Footer string // short label in the mode's controls line, empty to leave it to the help
+}
+
+// defaultKeymap lists the bindings of every mode in the order the help shows them
+var defaultKeymap = map[string][]keyBinding{
+	keyModeList: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Move down", Group: "Navigation"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Move up", Group: "Navigation"},
+		{Action: "page_down", Keys: []string{"pgdown", "ctrl+f"}, Help: "Move down a page", Group: "Navigation"},
+		{Action: "page_up", Keys: []string{"pgup", "ctrl+b"}, Help: "Move up a page", Group: "Navigation"},
+		{Action: "top", Keys: []string{"home"}, Help: "Go to the first commit", Group: "Navigation"},
+		{Action: "bottom", Keys: []string{"end"}, Help: "Go to the last commit", Group: "Navigation"},
+		{Action: "toggle", Keys: []string{"enter", " "}, Help: "Select or deselect the commit", Group: "Selection", Footer: "toggle"},
+		{Action: "range", Keys: []string{"r"}, Help: "Start a range selection, or select the range", Group: "Selection", Footer: "range"},
+		{Action: "select_all", Keys: []string{"a"}, Help: "Select all visible commits", Group: "Selection", Footer: "select all"},
+		{Action: "clear", Keys: []string{"c"}, Help: "Clear the selection", Group: "Selection", Footer: "clear"},
+		{Action: "select_ticket", Keys: []string{"t"}, Help: "Select every commit of the current ticket", Group: "Selection"},
+		{Action: "preview", Keys: []string{"p", "tab"}, Help: "Full-screen preview", Group: "Views", Footer: "preview"},
+		{Action: "diff_viewer", Keys: []string{"D"}, Help: "Full-screen diff viewer", Group: "Views", Footer: "diff"},
+		{Action: "preview_pane", Keys: []string{"v"}, Help: "Show or hide the live preview pane", Group: "Views"},
+		{Action: "preview_down", Keys: []string{"J"}, Help: "Scroll the preview down a line", Group: "Views"},
+		{Action: "preview_up", Keys: []string{"K"}, Help: "Scroll the preview up a line", Group: "Views"},
+		{Action: "preview_half_down", Keys: []string{"ctrl+d"}, Help: "Scroll the preview down half a page", Group: "Views"},
+		{Action: "preview_half_up", Keys: []string{"ctrl+u"}, Help: "Scroll the preview up half a page", Group: "Views"},
+		{Action: "detail", Keys: []string{"d"}, Help: "Toggle the detail view", Group: "Views"},
+		{Action: "reverse", Keys: []string{"R"}, Help: "Reverse the commit order", Group: "Views"},
+		{Action: "group_tickets", Keys: []string{"g"}, Help: "Group commits by ticket", Group: "Views"},
+		{Action: "collapse_group", Keys: []string{"z"}, Help: "Collapse or expand the current ticket group", Group: "Views"},
+		{Action: "search", Keys: []string{"/"}, Help: "Search commits", Group: "Filters", Footer: "search"},
+		{Action: "clear_search", Keys: []string{"esc"}, Help: "Clear a kept search", Group: "Filters"},
+		{Action: "diff_search", Keys: []string{"S"}, Help: "Search inside diffs (git log -S/-G)", Group: "Filters"},
+		{Action: "paths", Keys: []string{"P"}, Help: "Scope the list to paths or globs", Group: "Filters"},
+		{Action: "type_filter", Keys: []string{"T"}, Help: "Filter by commit type", Group: "Filters"},
+		{Action: "authors", Keys: []string{"A"}, Help: "Choose authors", Group: "Filters"},
+		{Action: "hide_applied", Keys: []string{"H"}, Help: "Hide already applied commits", Group: "Filters"},
+		{Action: "target_branch", Keys: []string{"b"}, Help: "Switch the target branch", Group: "Branches"},
+		{Action: "source_branch", Keys: []string{"B"}, Help: "Switch the source branch", Group: "Branches"},
+		{Action: "execute", Keys: []string{"e", "x"}, Help: "Cherry-pick the selected commits", Group: "Actions", Footer: "execute"},
+		{Action: "rebase", Keys: []string{"i"}, Help: "Interactive rebase of the selected commits", Group: "Actions"},
+		{Action: "override_policy", Keys: []string{"O"}, Help: "Override branch policy violations", Group: "Actions"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Group: "Actions", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit", Group: "Actions", Footer: "quit"},
+	},
+	keyModeSearch: {
+		{Action: "keep", Keys: []string{"enter"}, Help: "Exit search and keep the filter", Footer: "keep filter"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Exit search and clear it", Footer: "exit search"},
+		{Action: "down", Keys: []string{"down"}, Help: "Move down", Footer: "down"},
+		{Action: "up", Keys: []string{"up"}, Help: "Move up", Footer: "up"},
+		{Action: "toggle", Keys: []string{"tab"}, Help: "Select or deselect the commit", Footer: "toggle"},
+		{Action: "delete", Keys: []string{"backspace"}, Help: "Delete the last character", Footer: "delete"},
+		{Action: "help", Keys: []string{"f1"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+	keyModePreview: {
+		{Action: "preview", Keys: []string{"p", "tab", "esc"}, Help: "Back to the list", Footer: "exit preview"},
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Preview the next commit", Footer: "next"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Preview the previous commit", Footer: "previous"},
+		{Action: "preview_down", Keys: []string{"J"}, Help: "Scroll down a line", Footer: "scroll"},
+		{Action: "preview_up", Keys: []string{"K"}, Help: "Scroll up a line"},
+		{Action: "preview_half_down", Keys: []string{"ctrl+d"}, Help: "Scroll down half a page"},
+		{Action: "preview_half_up", Keys: []string{"ctrl+u"}, Help: "Scroll up half a page"},
+		{Action: "toggle", Keys: []string{" ", "enter"}, Help: "Select or deselect the commit", Footer: "toggle"},
+		{Action: "diff_viewer", Keys: []string{"D"}, Help: "Full-screen diff viewer", Footer: "full diff"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit", Footer: "quit"},
+	},
+	keyModeDiff: {
+		{Action: "down", Keys: []string{"j", "down"}, Help: "Scroll down a line", Group: "Scrolling", Footer: "scroll"},
+		{Action: "up", Keys: []string{"k", "up"}, Help: "Scroll up a line", Group: "Scrolling"},
+		{Action: "page_down", Keys: []string{" ", "pgdown", "ctrl+f"}, Help: "Scroll down a page", Group: "Scrolling", Footer: "page down"},
+		{Action: "page_up", Keys: []string{"b", "pgup", "ctrl+b"}, Help: "Scroll up a page", Group: "Scrolling"},
+		{Action: "half_down", Keys: []string{"ctrl+d"}, Help: "Scroll down half a page", Group: "Scrolling"},
+		{Action: "half_up", Keys: []string{"ctrl+u"}, Help: "Scroll up half a page", Group: "Scrolling"},
+		{Action: "top", Keys: []string{"g", "home"}, Help: "Jump to the top", Group: "Scrolling"},
+		{Action: "bottom", Keys: []string{"G", "end"}, Help: "Jump to the bottom", Group: "Scrolling"},
+		{Action: "next_hunk", Keys: []string{"n"}, Help: "Jump to the next hunk", Group: "Jumps", Footer: "next hunk"},
+		{Action: "prev_hunk", Keys: []string{"N"}, Help: "Jump to the previous hunk", Group: "Jumps"},
+		{Action: "next_file", Keys: []string{"]", "tab"}, Help: "Jump to the next file", Group: "Jumps", Footer: "next file"},
+		{Action: "prev_file", Keys: []string{"[", "shift+tab"}, Help: "Jump to the previous file", Group: "Jumps"},
+		{Action: "collapse_file", Keys: []string{"enter", "o"}, Help: "Collapse or expand the file at the top", Group: "Layout", Footer: "collapse"},
+		{Action: "side_by_side", Keys: []string{"s"}, Help: "Toggle unified/side-by-side", Group: "Layout", Footer: "side-by-side"},
+		{Action: "close", Keys: []string{"esc", "q", "D"}, Help: "Close the viewer", Group: "Layout", Footer: "back"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Group: "Layout", Footer: "help"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit", Group: "Layout"},
+	},
+	keyModeConflict: {
+		{Action: "editor", Keys: []string{"1"}, Help: "Choose an editor to resolve conflicts", Footer: "editor"},
+		{Action: "skip", Keys: []string{"2", "s"}, Help: "Skip this commit", Footer: "skip"},
+		{Action: "abort", Keys: []string{"3", "a"}, Help: "Abort the cherry-pick", Footer: "abort"},
+		{Action: "continue", Keys: []string{"4", "c"}, Help: "Continue after manual resolution", Footer: "continue"},
+		{Action: "refresh", Keys: []string{"r"}, Help: "Refresh the conflict status", Footer: "refresh"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Exit conflict mode", Footer: "exit"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show all keys", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit"},
+	},
+	keyModeEditor: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Next editor", Footer: "navigate"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Previous editor"},
+		{Action: "choose", Keys: []string{"enter", " "}, Help: "Open the editor", Footer: "select editor"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Back to the conflict options", Footer: "back"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit", Footer: "quit"},
+	},
+	keyModeBranch: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Next branch", Footer: "navigate"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Previous branch"},
+		{Action: "choose", Keys: []string{"enter"}, Help: "Switch to the branch and reload commits", Footer: "select branch"},
+		{Action: "refresh", Keys: []string{"r"}, Help: "Refresh the branch list", Footer: "refresh"},
+		{Action: "branch_scope", Keys: []string{"tab"}, Help: "Show local, remote or all branches", Footer: "local/remote/all"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Cancel and go back", Footer: "cancel"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit"},
+	},
+	keyModeAuthor: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Next author", Footer: "navigate"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Previous author"},
+		{Action: "page_down", Keys: []string{"pgdown", "ctrl+f"}, Help: "Next page of authors", Footer: "page"},
+		{Action: "page_up", Keys: []string{"pgup", "ctrl+b"}, Help: "Previous page of authors"},
+		{Action: "toggle", Keys: []string{" "}, Help: "Toggle the author, team or all authors", Footer: "toggle"},
+		{Action: "all_authors", Keys: []string{"a"}, Help: "Toggle all authors", Footer: "all authors"},
+		{Action: "apply", Keys: []string{"enter"}, Help: "Apply the selection and reload commits", Footer: "apply"},
+		{Action: "search", Keys: []string{"/"}, Help: "Search authors", Footer: "search"},
+		{Action: "refresh", Keys: []string{"r"}, Help: "Refresh the author list"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Cancel and go back", Footer: "cancel"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit", Footer: "quit"},
+	},
+	keyModeAuthorSearch: {
+		{Action: "keep", Keys: []string{"enter"}, Help: "Exit search and keep the filter", Footer: "keep filter"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Exit search and clear it", Footer: "exit search"},
+		{Action: "down", Keys: []string{"down"}, Help: "Next author", Footer: "down"},
+		{Action: "up", Keys: []string{"up"}, Help: "Previous author", Footer: "up"},
+		{Action: "toggle", Keys: []string{" "}, Help: "Toggle the author, team or all authors", Footer: "toggle"},
+		{Action: "delete", Keys: []string{"backspace"}, Help: "Delete the last character"},
+		{Action: "help", Keys: []string{"f1"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+	keyModeTypeFilter: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Next type", Footer: "navigate"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Previous type"},
+		{Action: "toggle", Keys: []string{" "}, Help: "Show or hide the type", Footer: "toggle type"},
+		{Action: "clear", Keys: []string{"c"}, Help: "Show all types", Footer: "show all"},
+		{Action: "close", Keys: []string{"enter", "esc", "T"}, Help: "Done", Footer: "done"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit", Footer: "quit"},
+	},
+	keyModePaths: {
+		{Action: "apply", Keys: []string{"enter"}, Help: "Scope the list to the paths; empty clears the scope", Footer: "apply"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Cancel", Footer: "cancel"},
+		{Action: "delete", Keys: []string{"backspace"}, Help: "Delete the last character"},
+		{Action: "help", Keys: []string{"f1"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+	keyModePickaxe: {
+		{Action: "search", Keys: []string{"enter"}, Help: "Search the diffs; empty clears the search", Footer: "search"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Cancel", Footer: "cancel"},
+		{Action: "delete", Keys: []string{"backspace"}, Help: "Delete the last character"},
+		{Action: "help", Keys: []string{"f1"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+	keyModeProtect: {
+		{Action: "confirm", Keys: []string{"enter"}, Help: "Confirm the typed branch name and cherry-pick", Footer: "confirm"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Cancel", Footer: "cancel"},
+		{Action: "delete", Keys: []string{"backspace"}, Help: "Delete the last character"},
+		{Action: "help", Keys: []string{"f1"}, Help: "Show this help", Footer: "help"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+	keyModePushPreview: {
+		{Action: "push", Keys: []string{"y", "enter"}, Help: "Push the commits", Footer: "push"},
+		{Action: "skip", Keys: []string{"n", "esc", "q", "ctrl+c"}, Help: "Keep the commits local", Footer: "keep local"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help", Footer: "help"},
+	},
+	keyModeVerify: {
+		{Action: "revert", Keys: []string{"r"}, Help: "Revert the failed pick, or every pick after a final check"},
+		{Action: "continue", Keys: []string{"c"}, Help: "Keep the picks and continue"},
+		{Action: "abort", Keys: []string{"a"}, Help: "Abort the run and roll back"},
+		{Action: "stop", Keys: []string{"q", "ctrl+c"}, Help: "Stop here and leave the picks in place"},
+		{Action: "help", Keys: []string{"?"}, Help: "Show this help"},
+	},
+	keyModeStartBranch: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Next branch", Footer: "navigate"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Previous branch"},
+		{Action: "choose", Keys: []string{"enter", " "}, Help: "Select the branch", Footer: "select"},
+		{Action: "search", Keys: []string{"/", "f"}, Help: "Search branches", Footer: "search"},
+		{Action: "branch_scope", Keys: []string{"tab"}, Help: "Show local, remote or all branches", Footer: "local/remote/all"},
+		{Action: "quit", Keys: []string{"q", "ctrl+c"}, Help: "Quit", Footer: "quit"},
+	},
+	keyModeStartSearch: {
+		{Action: "keep", Keys: []string{"enter"}, Help: "Exit search and keep the filter", Footer: "keep filter"},
+		{Action: "close", Keys: []string{"esc"}, Help: "Exit search and clear it", Footer: "clear"},
+		{Action: "delete", Keys: []string{"backspace"}, Help: "Delete the last character"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+	keyModeHelp: {
+		{Action: "down", Keys: []string{"down", "j"}, Help: "Scroll down", Footer: "scroll"},
+		{Action: "up", Keys: []string{"up", "k"}, Help: "Scroll up"},
+		{Action: "close", Keys: []string{"esc", "q", "?", "f1"}, Help: "Close the help", Footer: "close"},
+		{Action: "quit", Keys: []string{"ctrl+c"}, Help: "Quit"},
+	},
+}
+
+// Key presets (keys.preset): the keys each preset binds differently from the default keymap,
+// by mode and action
+var keyPresets = map[string]map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		keyModeList: {
+			"bottom":       {"G", "end"},
+			"preview_down": {"J", "ctrl+e"},
+			"preview_up":   {"K", "ctrl+y"},
+		},
+		keyModePreview: {
+			"preview_down": {"J", "ctrl+e"},
+			"preview_up":   {"K", "ctrl+y"},
+		},
+		keyModeDiff: {
+			"down": {"j", "down", "ctrl+e"},
+			"up":   {"k", "up", "ctrl+y"},
+		},
+		keyModeAuthor: {
+			"page_down": {"ctrl+f", "pgdown"},
+			"page_up":   {"ctrl+b", "pgup"},
+		},
+		keyModeHelp: {
+			"down": {"j", "down", "ctrl+e"},
+			"up":   {"k", "up", "ctrl+y"},
+		},
+	},
+	"emacs": {
+		keyModeList: {
+			"down":         {"ctrl+n", "down"},
+			"up":           {"ctrl+p", "up"},
+			"page_down":    {"ctrl+v", "pgdown"},
+			"page_up":      {"alt+v", "pgup"},
+			"top":          {"alt+<", "home"},
+			"bottom":       {"alt+>", "end"},
+			"search":       {"ctrl+s", "/"},
+			"clear_search": {"ctrl+g", "esc"},
+		},
+		keyModeSearch: {
+			"close": {"ctrl+g", "esc"},
+			"down":  {"ctrl+n", "down"},
+			"up":    {"ctrl+p", "up"},
+		},
+		keyModePreview: {
+			"preview":           {"ctrl+g", "p", "tab", "esc"},
+			"down":              {"ctrl+n", "down"},
+			"up":                {"ctrl+p", "up"},
+			"preview_half_down": {"ctrl+v"},
+			"preview_half_up":   {"alt+v"},
+		},
+		keyModeDiff: {
+			"down":      {"ctrl+n", "down"},
+			"up":        {"ctrl+p", "up"},
+			"page_down": {"ctrl+v", " ", "pgdown"},
+			"page_up":   {"alt+v", "pgup"},
+			"top":       {"alt+<", "home"},
+			"bottom":    {"alt+>", "end"},
+			"close":     {"ctrl+g", "esc", "q"},
+		},
+		keyModeConflict: {
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModeEditor: {
+			"down":  {"ctrl+n", "down"},
+			"up":    {"ctrl+p", "up"},
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModeBranch: {
+			"down":  {"ctrl+n", "down"},
+			"up":    {"ctrl+p", "up"},
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModeAuthor: {
+			"down":      {"ctrl+n", "down"},
+			"up":        {"ctrl+p", "up"},
+			"page_down": {"ctrl+v", "pgdown"},
+			"page_up":   {"alt+v", "pgup"},
+			"search":    {"ctrl+s", "/"},
+			"close":     {"ctrl+g", "esc"},
+		},
+		keyModeAuthorSearch: {
+			"close": {"ctrl+g", "esc"},
+			"down":  {"ctrl+n", "down"},
+			"up":    {"ctrl+p", "up"},
+		},
+		keyModeTypeFilter: {
+			"down":  {"ctrl+n", "down"},
+			"up":    {"ctrl+p", "up"},
+			"close": {"ctrl+g", "enter", "esc", "T"},
+		},
+		keyModePaths: {
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModePickaxe: {
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModeProtect: {
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModePushPreview: {
+			"skip": {"n", "ctrl+g", "esc", "q", "ctrl+c"},
+		},
+		keyModeStartBranch: {
+			"down":   {"ctrl+n", "down"},
+			"up":     {"ctrl+p", "up"},
+			"search": {"ctrl+s", "/"},
+		},
+		keyModeStartSearch: {
+			"close": {"ctrl+g", "esc"},
+		},
+		keyModeHelp: {
+			"down":  {"ctrl+n", "down"},
+			"up":    {"ctrl+p", "up"},
+			"close": {"ctrl+g", "esc", "q", "?", "f1"},
+		},
+	},
+}
+
+// Modes where printable keys are typed into a query rather than bound
+var textKeyModes = map[string]bool{
+	keyModeSearch:       true,
+	keyModeAuthorSearch: true,
+	keyModePaths:        true,
+	keyModePickaxe:      true,
+	keyModeProtect:      true,
+	keyModeStartSearch:  true,
+}
+
+// keyAliases maps key names accepted in the config to the names tea reports
+var keyAliases = map[string]string{
+	"space":    " ",
+	"return":   "enter",
+	"escape":   "esc",
+	"pagedown": "pgdown",
+	"pgdn":     "pgdown",
+	"pageup":   "pgup",
+	"backtab":  "shift+tab",
+}
+
+// normalizeKey returns tea's name for a configured key
+func normalizeKey(key string) string {
+	if alias, ok := keyAliases[strings.ToLower(key)]; ok {
+		return alias
+	}
+	return key
+}
+
+// buildKeymap applies a preset and the configured bindings to the default keymap, and checks
+// the result: modes and actions must exist, a key may trigger only one action per mode, and
+// modes that take typed text can't bind printable keys
+func buildKeymap(config KeysConfig) (map[string][]keyBinding, error) {
+	preset := config.Preset
+	if preset == "" {
+		preset = "default"
+	}
+	presetKeys, ok := keyPresets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown keys preset %q (use default, vim or emacs)", preset)
+	}
+
+	keymap := make(map[string][]keyBinding, len(defaultKeymap))
+	for mode, bindings := range defaultKeymap {
+		keymap[mode] = append([]keyBinding(nil), bindings...)
+	}
+
+	// Replace the keys of the preset's actions, then of the configured ones
+	for _, overrides := range []map[string]map[string][]string{presetKeys, config.Bindings} {
+		for _, mode := range sortedKeys(overrides) {
+			bindings, ok := keymap[mode]
+			if !ok {
+				return nil, fmt.Errorf("keys.bindings: unknown mode %q", mode)
+			}
+			for _, action := range sortedKeys(overrides[mode]) {
+				found := false
+				for i := range bindings {
+					if bindings[i].Action != action {
+						continue
+					}
+					keys := make([]string, len(overrides[mode][action]))
+					for k, key := range overrides[mode][action] {
+						keys[k] = normalizeKey(key)
+					}
+					bindings[i].Keys = keys
+					found = true
+				}
+				if !found {
+					return nil, fmt.Errorf("keys.bindings.%s: unknown action %q", mode, action)
+				}
+			}
+		}
+	}
+
+	for _, mode := range sortedKeys(keymap) {
+		bound := make(map[string]string)
+		for _, binding := range keymap[mode] {
+			for _, key := range binding.Keys {
+				if other, ok := bound[key]; ok && other != binding.Action {
+					return nil, fmt.Errorf("keys: %q is bound to both %s and %s in %s mode", keyLabel(key), other, binding.Action, mode)
+				}
+				bound[key] = binding.Action
+				if textKeyModes[mode] && key != " " && utf8.RuneCountInString(key) == 1 {
+					return nil, fmt.Errorf("keys: %q can't be bound in %s mode, where it is typed into the query", key, mode)
+				}
+			}
+		}
+	}
+	return keymap, nil
+}
+
+// sortedKeys returns the keys of a map in order, for deterministic errors
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keyBindings returns the bindings of a mode: the configured keymap, or the default one
+func (cp *CherryPicker) keyBindings(mode string) []keyBinding {
+	return bindingsOf(cp.keymap, mode)
+}
+
+// bindingsOf returns the bindings of a mode in keymap, or the default ones when keymap is nil
+func bindingsOf(keymap map[string][]keyBinding, mode string) []keyBinding {
+	if keymap != nil {
+		return keymap[mode]
+	}
+	return defaultKeymap[mode]
+}
+
+// keyFor returns the keys of an action for hints like "press ESC to go back"
+func (cp *CherryPicker) keyFor(mode, action string) string {
+	for _, binding := range cp.keyBindings(mode) {
+		if binding.Action == action && len(binding.Keys) > 0 {
+			return bindingLabel(binding, 2)
+		}
+	}
+	return "(unbound)"
+}
+
+// keyAction returns the action a key is bound to in a mode, or "" if it's unbound
+func (cp *CherryPicker) keyAction(mode string, msg tea.KeyMsg) string {
+	return bindingAction(cp.keyBindings(mode), msg)
+}
+
+// bindingAction returns the action of the binding a key belongs to, or "" if it's unbound
+func bindingAction(bindings []keyBinding, msg tea.KeyMsg) string {
+	key := msg.String()
+	for _, binding := range bindings {
+		for _, k := range binding.Keys {
+			if k == key {
+				return binding.Action
+			}
+		}
+	}
+	return ""
+}
+
+// keyLabel returns how a key is shown to the user
+func keyLabel(key string) string {
+	switch key {
+	case " ":
+		return "SPACE"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case "enter", "esc", "tab", "backspace", "home", "end":
+		return strings.ToUpper(key)
+	case "shift+tab":
+		return "shift+TAB"
+	case "f1":
+		return "F1"
+	}
+	return key
+}
+
+// bindingLabel returns the keys of a binding as shown to the user, at most limit of them (0 for
+// all). Keys are separated by "/", or by "or" when one of them is "/".
+func bindingLabel(binding keyBinding, limit int) string {
+	keys := binding.Keys
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	separator := "/"
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = keyLabel(key)
+		if strings.Contains(labels[i], "/") {
+			separator = " or "
+		}
+	}
+	return strings.Join(labels, separator)
+}
+
+// controlItems returns the "keys=label" items of a mode's controls line
+func (cp *CherryPicker) controlItems(mode string) []string {
+	return bindingControlItems(cp.keyBindings(mode))
+}
+
+// bindingControlItems returns the "keys=label" items of the bindings that have a controls label
+func bindingControlItems(bindings []keyBinding) []string {
+	var items []string
+	for _, binding := range bindings {
+		if binding.Footer != "" && len(binding.Keys) > 0 {
+			items = append(items, bindingLabel(binding, 2)+"="+binding.Footer)
+		}
+	}
+	return items
+}
+
+// controlsLine returns a mode's controls line
+func (cp *CherryPicker) controlsLine(mode string) string {
+	return "Controls: " + strings.Join(cp.controlItems(mode), ", ")
+}
+
+// keyOptions lists the bindings of a mode that have a controls label, one per line
+func (cp *CherryPicker) keyOptions(mode string) string {
+	var s strings.Builder
+	for _, binding := range cp.keyBindings(mode) {
+		if binding.Footer != "" && len(binding.Keys) > 0 {
+			s.WriteString(fmt.Sprintf("• %s = %s\n", bindingLabel(binding, 0), binding.Help))
+		}
+	}
+	return s.String()
+}
+
+// currentKeyMode returns the mode whose bindings are active
+func (cp *CherryPicker) currentKeyMode() string {
+	switch {
+	case cp.diffViewMode:
+		return keyModeDiff
+	case cp.searchMode:
+		return keyModeSearch
+	case cp.protectConfirmMode:
+		return keyModeProtect
+	case cp.pushPreviewMode:
+		return keyModePushPreview
+	case cp.verifyMode:
+		return keyModeVerify
+	case cp.conflictMode && cp.editorMode:
+		return keyModeEditor
+	case cp.conflictMode:
+		return keyModeConflict
+	case cp.branchMode:
+		return keyModeBranch
+	case cp.authorMode && cp.authorSearchMode:
+		return keyModeAuthorSearch
+	case cp.authorMode:
+		return keyModeAuthor
+	case cp.typeFilterMode:
+		return keyModeTypeFilter
+	case cp.pathMode:
+		return keyModePaths
+	case cp.pickaxeMode:
+		return keyModePickaxe
+	case cp.previewMode:
+		return keyModePreview
+	}
+	return keyModeList
+}
+
+// openHelp shows the help overlay for the current mode
+func (cp *CherryPicker) openHelp() {
+	cp.helpMode = true
+	cp.helpKeyMode = cp.currentKeyMode()
+	cp.helpScroll = 0
+}
+
+// handleHelpInput handles keyboard input while the help overlay is shown
+func (cp *CherryPicker) handleHelpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModeHelp, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "close":
+		cp.helpMode = false
+	case "down":
+		cp.helpScroll++
+	case "up":
+		cp.helpScroll = max(cp.helpScroll-1, 0)
+	}
+	return cp, nil
+}
+
+// helpLines lists a mode's bound actions and their names under their group headings
+func (cp *CherryPicker) helpLines(mode string) []string {
+	var bindings []keyBinding
+	for _, binding := range cp.keyBindings(mode) {
+		if len(binding.Keys) > 0 {
+			bindings = append(bindings, binding)
+		}
+	}
+	keyWidth := 0
+	for _, binding := range bindings {
+		keyWidth = max(keyWidth, lipgloss.Width(bindingLabel(binding, 0)))
+	}
+
+	var lines []string
+	group := ""
+	for _, binding := range bindings {
+		if binding.Group != group {
+			if group != "" {
+				lines = append(lines, "")
+			}
+			group = binding.Group
+			lines = append(lines, "\033[1m"+group+"\033[0m")
+		}
+		// The action name is what keys.bindings in the config rebinds
+		keys := bindingLabel(binding, 0)
+		lines = append(lines, fmt.Sprintf("  \033[36m%s\033[0m%s  %s \033[2m(%s)\033[0m", keys, strings.Repeat(" ", keyWidth-lipgloss.Width(keys)),
+			binding.Help, binding.Action))
+	}
+	return lines
+}
+
+// renderHelpView renders the help overlay: the current mode's bindings in a box in the middle
+// of the window, scrolling when they don't fit
+func (cp *CherryPicker) renderHelpView() string {
+	width, height := cp.windowSize()
+	lines := cp.helpLines(cp.helpKeyMode)
+	controls := cp.controlsLine(keyModeHelp)
+
+	// The box's border and the title, controls and blank lines around the bindings take 6 lines
+	rows := max(height-6, 1)
+	cp.helpScroll = min(cp.helpScroll, max(len(lines)-rows, 0))
+	end := min(cp.helpScroll+rows, len(lines))
+	if cp.helpScroll > 0 || end < len(lines) {
+		controls += fmt.Sprintf(" · lines %d-%d of %d", cp.helpScroll+1, end, len(lines))
+	}
+
+	body := []string{"❓ Keys: " + keyModeTitles[cp.helpKeyMode], ""}
+	body = append(body, lines[cp.helpScroll:end]...)
+	body = append(body, "", "\033[2m"+controls+"\033[0m")
+
+	// Cut lines to the window, less the border and padding
+	inner := 0
+	for _, line := range body {
+		inner = max(inner, lipgloss.Width(line))
+	}
+	inner = min(inner, max(width-6, 1))
+	body = fitLines(body, inner, len(body))
+
+	box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 2).Render(strings.Join(body, "\n"))
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildKeymap(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  KeysConfig
+		mode    string
+		action  string
+		want    []string
+		wantErr string
+	}{
+		{name: "default preset", config: KeysConfig{}, mode: keyModeList, action: "down", want: []string{"down", "j"}},
+		{name: "emacs preset", config: KeysConfig{Preset: "emacs"}, mode: keyModeDiff, action: "close", want: []string{"ctrl+g", "esc", "q"}},
+		{name: "override with aliases", config: KeysConfig{Bindings: map[string]map[string][]string{
+			keyModeList: {"clear_search": {"ctrl+l", "Escape"}},
+		}}, mode: keyModeList, action: "clear_search", want: []string{"ctrl+l", "esc"}},
+		{name: "override replaces the preset", config: KeysConfig{Preset: "emacs", Bindings: map[string]map[string][]string{
+			keyModeHelp: {"close": {"esc"}},
+		}}, mode: keyModeHelp, action: "close", want: []string{"esc"}},
+		{name: "empty list unbinds", config: KeysConfig{Bindings: map[string]map[string][]string{
+			keyModeList: {"rebase": {}},
+		}}, mode: keyModeList, action: "rebase", want: []string{}},
+		{name: "unknown preset", config: KeysConfig{Preset: "nano"}, wantErr: `unknown keys preset "nano"`},
+		{name: "unknown mode", config: KeysConfig{Bindings: map[string]map[string][]string{
+			"lists": {"down": {"n"}},
+		}}, wantErr: `unknown mode "lists"`},
+		{name: "unknown action", config: KeysConfig{Bindings: map[string]map[string][]string{
+			keyModeList: {"explode": {"X"}},
+		}}, wantErr: `keys.bindings.list: unknown action "explode"`},
+		{name: "conflicting override", config: KeysConfig{Bindings: map[string]map[string][]string{
+			keyModeList: {"execute": {"j"}},
+		}}, wantErr: `"j" is bound to both`},
+		{name: "same key in different modes", config: KeysConfig{Bindings: map[string]map[string][]string{
+			keyModeList:   {"execute": {"X"}},
+			keyModeBranch: {"refresh": {"X"}},
+		}}, mode: keyModeBranch, action: "refresh", want: []string{"X"}},
+		{name: "printable key in a text mode", config: KeysConfig{Bindings: map[string]map[string][]string{
+			keyModePaths: {"apply": {"a"}},
+		}}, wantErr: "typed into the query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keymap, err := buildKeymap(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildKeymap: %v", err)
+			}
+			for _, binding := range keymap[tt.mode] {
+				if binding.Action == tt.action {
+					if !reflect.DeepEqual(binding.Keys, tt.want) {
+						t.Errorf("%s.%s = %v, want %v", tt.mode, tt.action, binding.Keys, tt.want)
+					}
+					return
+				}
+			}
+			t.Errorf("%s has no %s action", tt.mode, tt.action)
+		})
+	}
+}
+
+func TestBuildKeymapLeavesDefaultsAlone(t *testing.T) {
+	before := bindingLabel(defaultKeymap[keyModeList][0], 0)
+	if _, err := buildKeymap(KeysConfig{Preset: "emacs"}); err != nil {
+		t.Fatal(err)
+	}
+	if after := bindingLabel(defaultKeymap[keyModeList][0], 0); after != before {
+		t.Errorf("default list keys changed from %s to %s", before, after)
+	}
+}
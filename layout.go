@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Layout sizes. Until the first tea.WindowSizeMsg arrives the view assumes a default window.
+const (
+	defaultWindowWidth  = 120
+	defaultWindowHeight = 40
+	sideBySideMinWidth  = 120 // narrower windows put the "auto" preview pane below the list
+	minPreviewHeight    = 6   // smaller windows hide the preview pane below the list
+	minListHeight       = 5   // smaller windows get a compact footer
+	maxSelectedLines    = 5   // selected commits listed under the list before "... and N more"
+)
+
+// Preview pane placements (ui.preview_pane)
+const (
+	previewPaneAuto   = "auto"
+	previewPaneRight  = "right"
+	previewPaneBottom = "bottom"
+	previewPaneOff    = "off"
+)
+
+// normalizePreviewPane returns a valid preview pane placement, defaulting to auto
+func normalizePreviewPane(placement string) string {
+	switch placement {
+	case previewPaneRight, previewPaneBottom, previewPaneOff:
+		return placement
+	}
+	return previewPaneAuto
+}
+
+// windowSize returns the terminal size
+func (cp *CherryPicker) windowSize() (int, int) {
+	width, height := cp.width, cp.height
+	if width <= 0 {
+		width = defaultWindowWidth
+	}
+	if height <= 0 {
+		height = defaultWindowHeight
+	}
+	return width, height
+}
+
+// handleWindowSize records the new terminal size
+func (cp *CherryPicker) handleWindowSize(msg tea.WindowSizeMsg) {
+	cp.width = msg.Width
+	cp.height = msg.Height
+}
+
+// previewPlacement returns where the live preview pane goes for the current window: right of
+// the list, below it, or off
+func (cp *CherryPicker) previewPlacement() string {
+	if cp.hidePreviewPane {
+		return previewPaneOff
+	}
+	width, _ := cp.windowSize()
+	placement := normalizePreviewPane(cp.config.UI.PreviewPane)
+	if placement == previewPaneAuto {
+		if width >= sideBySideMinWidth {
+			return previewPaneRight
+		}
+		return previewPaneBottom
+	}
+	return placement
+}
+
+// togglePreviewPane shows or hides the live preview pane
+func (cp *CherryPicker) togglePreviewPane() {
+	cp.hidePreviewPane = !cp.hidePreviewPane
+	cp.updatePreview()
+}
+
+// scrollPreview scrolls the preview by delta lines; renderPreviewPane keeps it in bounds
+func (cp *CherryPicker) scrollPreview(delta int) {
+	cp.previewScroll += delta
+	if cp.previewScroll < 0 {
+		cp.previewScroll = 0
+	}
+}
+
+// previewPageSize returns how many lines ctrl+d/ctrl+u scroll the preview
+func (cp *CherryPicker) previewPageSize() int {
+	_, height := cp.windowSize()
+	return max(height/4, 1)
+}
+
+// listPageSize returns how many commits PgUp/PgDn move: a list pane's height
+func (cp *CherryPicker) listPageSize() int {
+	if cp.listRows > 0 {
+		return cp.listRows
+	}
+	_, height := cp.windowSize()
+	return max(height/2, 1)
+}
+
+// lineCount returns the number of lines of s, ignoring a trailing newline
+func lineCount(s string) int {
+	if s == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(s, "\n"), "\n") + 1
+}
+
+// wrapText wraps text at word boundaries to the given width
+func wrapText(text string, width int) string {
+	return lipgloss.NewStyle().Width(width).Render(text)
+}
+
+// fitLines cuts or pads lines to exactly width cells and height lines, so panes can be placed
+// side by side. Truncated lines are reset so colors don't leak into the next pane.
+func fitLines(lines []string, width, height int) []string {
+	truncate := lipgloss.NewStyle().MaxWidth(width)
+	fitted := make([]string, height)
+	for i := range fitted {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+			if lipgloss.Width(line) > width {
+				line = truncate.Render(line) + "\033[0m"
+			}
+		}
+		if pad := width - lipgloss.Width(line); pad > 0 {
+			line += strings.Repeat(" ", pad)
+		}
+		fitted[i] = line
+	}
+	return fitted
+}
+
+// renderMainLayout renders the main view: the header, the commit list and live preview pane
+// filling the space the window leaves, and the footer
+func (cp *CherryPicker) renderMainLayout() string {
+	width, height := cp.windowSize()
+	visible := cp.getVisibleCommits()
+
+	// Keep the preview pane on the commit under the cursor however the cursor moved
+	placement := cp.previewPlacement()
+	if placement != previewPaneOff {
+		cp.updatePreview()
+	}
+
+	header := cp.renderListHeader(visible)
+	footer := cp.renderListFooter(width, false)
+	if height-lineCount(header)-lineCount(footer)-1 < minListHeight {
+		footer = cp.renderListFooter(width, true)
+	}
+	bodyHeight := max(height-lineCount(header)-lineCount(footer)-1, 3)
+
+	if placement == previewPaneBottom && bodyHeight < 2*minPreviewHeight {
+		placement = previewPaneOff
+	}
+
+	var body []string
+	switch placement {
+	case previewPaneRight:
+		listWidth := width / 2
+		previewWidth := width - listWidth - 3
+		list := fitLines(cp.renderCommitList(visible, bodyHeight), listWidth, bodyHeight)
+		preview := fitLines(strings.Split(cp.renderPreviewPane(previewWidth, bodyHeight), "\n"), previewWidth, bodyHeight)
+		for i := range list {
+			body = append(body, list[i]+" │ "+preview[i])
+		}
+	case previewPaneBottom:
+		listHeight := bodyHeight * 3 / 5
+		previewHeight := bodyHeight - listHeight - 1
+		body = fitLines(cp.renderCommitList(visible, listHeight), width, listHeight)
+		body = append(body, strings.Repeat("─", width))
+		body = append(body, fitLines(strings.Split(cp.renderPreviewPane(width, previewHeight), "\n"), width, previewHeight)...)
+	default:
+		body = fitLines(cp.renderCommitList(visible, bodyHeight), width, bodyHeight)
+	}
+
+	return header + strings.Join(body, "\n") + "\n\n" + footer
+}
+
+// renderCommitList renders the rows of the visible commits that fit in height lines, scrolling
+// just enough to keep the cursor in view
+func (cp *CherryPicker) renderCommitList(visible []Commit, height int) []string {
+	if len(visible) == 0 {
+		return nil
+	}
+
+	// Ensure cursor is within bounds
+	if cp.currentIndex >= len(visible) {
+		cp.currentIndex = len(visible) - 1
+	}
+	if cp.currentIndex < 0 {
+		cp.currentIndex = 0
+	}
+
+	// Reserve a line for the position when the list may not fit
+	rows := height
+	if len(visible) > height || cp.detailView || cp.groupByTicket {
+		rows = max(height-1, 1)
+	}
+
+	cp.listRows = rows
+	rangeStart, rangeEnd := cp.rangeRows(visible)
+
+	// Scroll up to the cursor, or down until the rows from the offset through the cursor fit.
+	// Every row takes at least a line, so start no further up than rows above the cursor.
+	if cp.listOffset > cp.currentIndex || cp.listOffset >= len(visible) {
+		cp.listOffset = cp.currentIndex
+	}
+	cp.listOffset = max(cp.listOffset, cp.currentIndex-rows+1)
+	for cp.listOffset < cp.currentIndex {
+		used := 0
+		for i := cp.listOffset; i <= cp.currentIndex; i++ {
+			used += lineCount(cp.renderCommitRow(visible, i, cp.listOffset, i >= rangeStart && i <= rangeEnd))
+		}
+		if used <= rows {
+			break
+		}
+		cp.listOffset++
+	}
+
+	var lines []string
+	last := cp.listOffset
+	for i := cp.listOffset; i < len(visible); i++ {
+		row := strings.Split(strings.TrimSuffix(cp.renderCommitRow(visible, i, cp.listOffset, i >= rangeStart && i <= rangeEnd), "\n"), "\n")
+		if len(lines)+len(row) > rows && i > cp.listOffset {
+			break
+		}
+		lines = append(lines, row...)
+		last = i
+	}
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+
+	if rows < height {
+		lines = append(lines, fmt.Sprintf("\033[2m── %d-%d of %d commits ──\033[0m", cp.listOffset+1, last+1, len(visible)))
+	}
+	return lines
+}
+
+// renderPreviewPane renders the part of the preview that fits in height lines from the
+// preview's scroll position, with the position on the last line when it doesn't all fit
+func (cp *CherryPicker) renderPreviewPane(width, height int) string {
+	if cp.previewCommit == nil {
+		return "📖 No commit to preview"
+	}
+
+	lines := strings.Split(strings.TrimRight(cp.previewContent(), "\n"), "\n")
+	rows := height
+	if len(lines) > height {
+		rows = height - 1
+	}
+	rows = max(rows, 1)
+
+	// Keep the scroll position in bounds
+	cp.previewScroll = min(cp.previewScroll, max(len(lines)-rows, 0))
+	end := min(cp.previewScroll+rows, len(lines))
+	window := lines[cp.previewScroll:end]
+
+	if rows < height {
+		mode := keyModeList
+		if cp.previewMode {
+			mode = keyModePreview
+		}
+		window = append(window, fmt.Sprintf("\033[2m── lines %d-%d of %d (%s/%s, %s/%s to scroll) ──\033[0m", cp.previewScroll+1, end, len(lines),
+			cp.keyFor(mode, "preview_down"), cp.keyFor(mode, "preview_up"), cp.keyFor(mode, "preview_half_down"), cp.keyFor(mode, "preview_half_up")))
+	}
+	return strings.Join(fitLines(window, width, len(window)), "\n")
+}
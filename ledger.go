@@ -0,0 +1,370 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LedgerEntry records what happened to one source commit during a run
+type LedgerEntry struct {
+	SourceSHA  string    `json:"source_sha"`
+	TargetSHA  string    `json:"target_sha,omitempty"` // Empty when the pick was skipped or reverted
+	Message    string    `json:"message,omitempty"`
+	Conflict   bool      `json:"conflict,omitempty"`
+	Resolution string    `json:"resolution,omitempty"` // How a conflict or failure was handled
+	Override   string    `json:"override,omitempty"`   // Branch policy violations the user overrode
+	Time       time.Time `json:"time"`
+}
+
+// LedgerRun records a single cherry-pick run
+type LedgerRun struct {
+	Session     string        `json:"session"`
+	User        string        `json:"user"`
+	Timestamp   time.Time     `json:"timestamp"`
+	Source      string        `json:"source"`
+	Target      string        `json:"target"`
+	Pushed      bool          `json:"pushed"`
+	PullRequest string        `json:"pull_request,omitempty"` // URL of the pull request a pull request run opened
+	Entries     []LedgerEntry `json:"entries"`
+}
+
+// Ledger is the persistent history of backports for a repository
+type Ledger struct {
+	Runs []LedgerRun `json:"runs"`
+
+	path string
+}
+
+// Backport describes where a source commit landed
+type Backport struct {
+	Target    string
+	TargetSHA string
+	User      string
+	Time      time.Time
+}
+
+// ledgerPath returns the ledger file location, defaulting to .git/cherry-picker/ledger.json
+func ledgerPath(config *Config) (string, error) {
+	if config.Ledger.Path != "" {
+		return config.Ledger.Path, nil
+	}
+
+	gitDir, err := gitCommonDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, "cherry-picker", "ledger.json"), nil
+}
+
+// gitCommonDir returns the .git directory shared by all worktrees of the repository
+func gitCommonDir() (string, error) {
+	output, err := exec.Command("git", "rev-parse", "--git-common-dir").Output()
+	if err != nil {
+		return "", fmt.Errorf("not inside a git repository")
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// LoadLedger reads the ledger, returning an empty one if it doesn't exist yet
+func LoadLedger(config *Config) (*Ledger, error) {
+	path, err := ledgerPath(config)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := &Ledger{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger: %v", err)
+	}
+
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse ledger: %v", err)
+	}
+	return ledger, nil
+}
+
+// Save writes the ledger back to disk
+func (l *Ledger) Save() error {
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("failed to create ledger directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ledger: %v", err)
+	}
+
+	if err := os.WriteFile(l.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write ledger: %v", err)
+	}
+	return nil
+}
+
+// run returns the run for a session, creating it if needed
+func (l *Ledger) run(session string) *LedgerRun {
+	for i := range l.Runs {
+		if l.Runs[i].Session == session {
+			return &l.Runs[i]
+		}
+	}
+	l.Runs = append(l.Runs, LedgerRun{Session: session})
+	return &l.Runs[len(l.Runs)-1]
+}
+
+// findRun returns the run for a session, or the latest run if session is empty
+func (l *Ledger) findRun(session string) (LedgerRun, error) {
+	if len(l.Runs) == 0 {
+		return LedgerRun{}, fmt.Errorf("no runs recorded in the ledger")
+	}
+	if session == "" {
+		return l.Runs[len(l.Runs)-1], nil
+	}
+	for _, run := range l.Runs {
+		if run.Session == session {
+			return run, nil
+		}
+	}
+	return LedgerRun{}, fmt.Errorf("no run recorded for session %s", session)
+}
+
+// BackportsOf returns the targets a source commit currently lives on, keeping only the
+// latest entry per target so reverted picks drop out
+func (l *Ledger) BackportsOf(sourceSHA string) []Backport {
+	latest := make(map[string]Backport)
+	var order []string
+
+	for _, run := range l.Runs {
+		for _, entry := range run.Entries {
+			if !sameCommit(entry.SourceSHA, sourceSHA) {
+				continue
+			}
+			if _, ok := latest[run.Target]; !ok {
+				order = append(order, run.Target)
+			}
+			latest[run.Target] = Backport{
+				Target:    run.Target,
+				TargetSHA: entry.TargetSHA,
+				User:      run.User,
+				Time:      entry.Time,
+			}
+		}
+	}
+
+	var backports []Backport
+	for _, target := range order {
+		if latest[target].TargetSHA != "" {
+			backports = append(backports, latest[target])
+		}
+	}
+	return backports
+}
+
+// ledgerRecord appends an entry for the current run and saves the ledger. The entry is also
+// kept on the picker so the run can be reported when the ledger is disabled.
+func (cp *CherryPicker) ledgerRecord(entry LedgerEntry) {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	// Store full SHAs so abbreviated ones from different clones still match
+	if output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", entry.SourceSHA+"^{commit}").Output(); err == nil {
+		entry.SourceSHA = strings.TrimSpace(string(output))
+	}
+	if entry.Message == "" {
+		entry.Message = getCommitSubject(entry.SourceSHA)
+	}
+	cp.runEntries = append(cp.runEntries, entry)
+
+	if !cp.config.Ledger.Enabled {
+		return
+	}
+
+	ledger, err := LoadLedger(cp.config)
+	if err != nil {
+		fmt.Printf("⚠️  Could not update ledger: %v\n", err)
+		return
+	}
+
+	run := ledger.run(cp.session())
+	if run.Timestamp.IsZero() {
+		run.Timestamp = time.Now()
+		run.User = cp.authorName
+		run.Source = cp.config.Git.SourceBranch
+		run.Target = cp.config.Git.TargetBranch
+	}
+	run.Entries = append(run.Entries, entry)
+
+	if err := ledger.Save(); err != nil {
+		fmt.Printf("⚠️  Could not update ledger: %v\n", err)
+	}
+}
+
+// recordPick records a successful pick in the ledger and the backport notes,
+// using the current HEAD as the target SHA
+func (cp *CherryPicker) recordPick(sha string, conflict bool, resolution string) {
+	targetSHA := ""
+	if output, err := exec.Command("git", "rev-parse", "HEAD").Output(); err == nil {
+		targetSHA = strings.TrimSpace(string(output))
+	}
+	if cp.pickedTargets == nil {
+		cp.pickedTargets = make(map[string]string)
+	}
+	cp.pickedTargets[sha] = targetSHA
+	cp.writeBackportNote(sha, targetSHA)
+	cp.ledgerRecord(LedgerEntry{
+		SourceSHA:  sha,
+		TargetSHA:  targetSHA,
+		Conflict:   conflict,
+		Resolution: resolution,
+		Override:   cp.policyOverrideNote(sha),
+	})
+}
+
+// ledgerMarkPushed flags the current run as pushed, with the pull request it opened if any
+func (cp *CherryPicker) ledgerMarkPushed() {
+	cp.runPushed = true
+	if !cp.config.Ledger.Enabled {
+		return
+	}
+
+	ledger, err := LoadLedger(cp.config)
+	if err != nil {
+		fmt.Printf("⚠️  Could not update ledger: %v\n", err)
+		return
+	}
+	run := ledger.run(cp.session())
+	run.Pushed = true
+	if cp.pullRequestURL != "" {
+		run.PullRequest = cp.pullRequestURL
+	}
+	if err := ledger.Save(); err != nil {
+		fmt.Printf("⚠️  Could not update ledger: %v\n", err)
+	}
+}
+
+// annotateBackports fills in Commit.Backports from the ledger and the shared backport notes,
+// marking commits noted on the current target as already applied
+func (cp *CherryPicker) annotateBackports() {
+	ledger := &Ledger{}
+	if cp.config.Ledger.Enabled {
+		if loaded, err := LoadLedger(cp.config); err == nil {
+			ledger = loaded
+		}
+	}
+	notes := cp.loadBackportNotes()
+	targetRef := cp.resolveTargetRef()
+
+	for i := range cp.commits {
+		commit := &cp.commits[i]
+		noted := notedBackportsOf(notes, commit.SHA)
+		commit.Backports = mergeBackports(ledger.BackportsOf(commit.SHA), noted)
+		if !commit.AlreadyApplied && cp.isNotedOnTarget(noted, targetRef) {
+			commit.AlreadyApplied = true
+		}
+	}
+}
+
+// sameCommit reports whether two possibly abbreviated SHAs name the same commit
+func sameCommit(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// otherBackports returns the backports of a commit to branches other than the current target
+func (cp *CherryPicker) otherBackports(commit Commit) []Backport {
+	var others []Backport
+	for _, backport := range commit.Backports {
+		if backport.Target != cp.config.Git.TargetBranch {
+			others = append(others, backport)
+		}
+	}
+	return others
+}
+
+// formatBackport renders a backport as "release/1.5 as abc12345 by alice"
+func formatBackport(backport Backport) string {
+	sha := backport.TargetSHA
+	if len(sha) > 8 {
+		sha = sha[:8]
+	}
+	text := fmt.Sprintf("%s as %s", backport.Target, sha)
+	if backport.User != "" {
+		text += " by " + backport.User
+	}
+	return text
+}
+
+// runHistory prints ledger entries for the `history` command
+func runHistory(config *Config, sha, target string, limit int) error {
+	ledger, err := LoadLedger(config)
+	if err != nil {
+		return err
+	}
+
+	type row struct {
+		run   LedgerRun
+		entry LedgerEntry
+	}
+	var rows []row
+	for _, run := range ledger.Runs {
+		if target != "" && run.Target != target {
+			continue
+		}
+		for _, entry := range run.Entries {
+			if sha != "" && !strings.HasPrefix(entry.SourceSHA, sha) && !strings.HasPrefix(entry.TargetSHA, sha) {
+				continue
+			}
+			rows = append(rows, row{run, entry})
+		}
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No backports recorded.")
+		return nil
+	}
+
+	// Show the most recent entries last, like git log --reverse
+	if limit > 0 && len(rows) > limit {
+		rows = rows[len(rows)-limit:]
+	}
+
+	for _, r := range rows {
+		source := r.entry.SourceSHA
+		if len(source) > 8 {
+			source = source[:8]
+		}
+		result := "skipped"
+		if r.entry.TargetSHA != "" {
+			result = r.entry.TargetSHA
+			if len(result) > 8 {
+				result = result[:8]
+			}
+		}
+
+		pushed := ""
+		if r.run.PullRequest != "" {
+			pushed = " (pull request " + r.run.PullRequest + ")"
+		} else if r.run.Pushed {
+			pushed = " (pushed)"
+		}
+		fmt.Printf("%s  %s → %s %s  %s  %s%s\n",
+			r.entry.Time.Format("2006-01-02 15:04"), source, r.run.Target, result, r.run.User, r.entry.Message, pushed)
+		if r.entry.Resolution != "" {
+			fmt.Printf("    ⚔️  %s\n", r.entry.Resolution)
+		}
+		if r.entry.Override != "" {
+			fmt.Printf("    ⛔ Policy %s\n", r.entry.Override)
+		}
+	}
+	return nil
+}
@@ -10,10 +10,38 @@ import (
 )
 
 func main() {
+	// Handle subcommands before the interactive flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "history":
+			runHistoryCommand(os.Args[2:])
+			return
+		case "report":
+			runReportCommand(os.Args[2:])
+			return
+		case "changelog":
+			runChangelogCommand(os.Args[2:])
+			return
+		}
+	}
+
 	var reverse bool
 	var generateConfig bool
+	var allAuthors bool
+	var query, target string
+	var yes bool
+	var source, since, until string
+	var paths pathList
 	flag.BoolVar(&reverse, "reverse", false, "display commits in reverse order (newest first)")
 	flag.BoolVar(&generateConfig, "generate-config", false, "generate default configuration file")
+	flag.BoolVar(&allAuthors, "all-authors", false, "list every author's commits instead of only yours")
+	flag.StringVar(&source, "source", "", "source rev or range, e.g. v1.2..v1.3 or refs/pull/42/head (skips source selection)")
+	flag.StringVar(&target, "target", "", "target branch (skips target selection when --source is also given)")
+	flag.StringVar(&query, "query", "", "preselect commits matching a filter query, e.g. 'author:alice type:fix -merge'")
+	flag.BoolVar(&yes, "yes", false, "with --query, cherry-pick the matching commits without opening the TUI")
+	flag.StringVar(&since, "since", "", "only list source commits newer than this date")
+	flag.StringVar(&until, "until", "", "only list source commits older than this date")
+	flag.Var(&paths, "path", "only list commits touching this path or glob, e.g. 'services/billing/**' (repeatable)")
 	flag.Parse()
 
 	// Handle config generation
@@ -36,12 +64,36 @@ func main() {
 	if reverse {
 		config.Behavior.DefaultReverse = true
 	}
+	if yes && query == "" {
+		fmt.Println("❌ --yes needs --query to select commits")
+		os.Exit(1)
+	}
+	selection, err := parseCommitQuery(query)
+	if err != nil {
+		fmt.Printf("❌ Invalid --query: %v\n", err)
+		os.Exit(1)
+	}
+
+	if allAuthors {
+		config.Authors.AllByDefault = true
+	}
+	if since != "" {
+		config.Git.Since = since
+	}
+	if until != "" {
+		config.Git.Until = until
+	}
+	if len(paths) > 0 {
+		config.Git.Paths = paths
+	}
 
 	// Interactive branch selection at startup
 	fmt.Println("🍒 Cherry Picker - Interactive Git Cherry-Pick Tool")
 	fmt.Println()
 	
-	sourceBranch, targetBranch, err := RunBranchSelector()
+	// The config was validated on load, keys included
+	keymap, _ := buildKeymap(config.Keys)
+	sourceBranch, targetBranch, err := RunBranchSelector(config.Git, keymap, source, target)
 	if err != nil {
 		if strings.Contains(err.Error(), "cancelled") {
 			// User chose to quit - exit gracefully without error message
@@ -59,11 +111,16 @@ func main() {
 	fmt.Println()
 
 	cp := &CherryPicker{
-		selected:    make(map[string]bool),
-		cursorBlink: true,
-		reverse:     config.Behavior.DefaultReverse,
-		config:      config,
+		selected:       make(map[string]bool),
+		cursorBlink:    true,
+		reverse:        config.Behavior.DefaultReverse,
+		config:         config,
+		typeFilter:     newTypeFilter(config.Conventional.Types),
+		branchScope:    normalizeBranchScope(config.Git.BranchScope),
+		paths:          cleanPaths(config.Git.Paths),
+		diffSideBySide: config.UI.DiffSideBySide,
 	}
+	cp.keymap = keymap
 
 	if err := cp.setup(); err != nil {
 		fmt.Printf("❌ Error: %v\n", err)
@@ -75,11 +132,29 @@ func main() {
 		return
 	}
 
-	// Run the TUI
-	p := tea.NewProgram(cp, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running TUI: %v\n", err)
-		os.Exit(1)
+	// Preselect the commits matching --query
+	if !selection.Empty() {
+		count := cp.selectByQuery(selection)
+		fmt.Printf("🧩 %s: %d commit(s) selected\n", selection.String(), count)
+		if yes {
+			if count == 0 {
+				fmt.Println("No commits selected. Exiting.")
+				return
+			}
+			for _, commit := range cp.getSelectedCommits() {
+				fmt.Printf("  ✓ %s\n", commit.Full)
+			}
+			cp.executeRequested = true
+		}
+	}
+
+	// Run the TUI unless --yes already chose what to execute
+	if !cp.executeRequested {
+		p := tea.NewProgram(cp, tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			fmt.Printf("Error running TUI: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
 	// Handle selected commits based on exit reason
@@ -111,26 +186,105 @@ func main() {
 
 	// Execute cherry-pick (back to original approach but with conflict handling)
 	if cp.executeRequested || (!cp.quitting) {
-		if err := cp.cherryPickWithConflictHandling(selectedSHAs); err != nil {
+		err := cp.cherryPickWithConflictHandling(selectedSHAs)
+		for err != nil && (strings.Contains(err.Error(), "VERIFY_FAILED") || strings.Contains(err.Error(), "PUSH_PREVIEW") ||
+			strings.Contains(err.Error(), "CONFLICT_DETECTED")) {
 			if strings.Contains(err.Error(), "CONFLICT_DETECTED") {
-				// Handle conflicts by restarting TUI in conflict mode
+				// Let the user resolve, skip or abort, then resume the run
 				fmt.Println("Entering conflict resolution mode...")
-				
-				// Restart TUI for conflict resolution
+				cp.quitting = false
 				p := tea.NewProgram(cp, tea.WithAltScreen())
-				if _, err := p.Run(); err != nil {
-					fmt.Printf("Error running conflict resolution TUI: %v\n", err)
+				if _, runErr := p.Run(); runErr != nil {
+					fmt.Printf("Error running conflict resolution TUI: %v\n", runErr)
 					os.Exit(1)
 				}
-				
-				// After conflict resolution TUI exits
-				if cp.quitting {
-					fmt.Println("Exited conflict resolution.")
+				err = cp.applyConflictDecision()
+				continue
+			}
+			if strings.Contains(err.Error(), "PUSH_PREVIEW") {
+				// Show what is about to be pushed and ask before pushing
+				cp.quitting = false
+				p := tea.NewProgram(cp, tea.WithAltScreen())
+				if _, runErr := p.Run(); runErr != nil {
+					fmt.Printf("Error running push preview TUI: %v\n", runErr)
+					os.Exit(1)
 				}
-			} else {
-				fmt.Printf("❌ Error: %v\n", err)
+				err = cp.applyPushDecision()
+				continue
+			}
+			
+			// Let the user decide how to continue after a failed verification
+			fmt.Println("Entering verification review mode...")
+			
+			cp.quitting = false
+			p := tea.NewProgram(cp, tea.WithAltScreen())
+			if _, runErr := p.Run(); runErr != nil {
+				fmt.Printf("Error running verification TUI: %v\n", runErr)
 				os.Exit(1)
 			}
+			err = cp.applyVerifyDecision()
 		}
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// runHistoryCommand queries the backport ledger: cherry-picker history [flags]
+func runHistoryCommand(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	sha := fs.String("sha", "", "show only entries for this source or target SHA (prefix)")
+	target := fs.String("target", "", "show only backports to this target branch")
+	limit := fs.Int("limit", 50, "maximum number of entries to show (0 for all)")
+	fs.Parse(args)
+
+	config, err := LoadConfig()
+	if err != nil {
+		fmt.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := runHistory(config, *sha, *target, *limit); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runReportCommand renders a release report for a recorded run: cherry-picker report [flags]
+func runReportCommand(args []string) {
+	config, err := LoadConfig()
+	if err != nil {
+		fmt.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("report", flag.ExitOnError)
+	session := fs.String("session", "", "report on this run session (default: the latest run)")
+	format := fs.String("format", config.Report.Format, "report format: markdown, html or csv")
+	output := fs.String("output", "-", "file to write the report to (- for stdout)")
+	fs.Parse(args)
+
+	if err := runReport(config, *session, *format, *output); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runChangelogCommand prints a changelog draft for a recorded run: cherry-picker changelog [flags]
+func runChangelogCommand(args []string) {
+	fs := flag.NewFlagSet("changelog", flag.ExitOnError)
+	session := fs.String("session", "", "draft the changelog for this run session (default: the latest run)")
+	fs.Parse(args)
+
+	config, err := LoadConfig()
+	if err != nil {
+		fmt.Printf("❌ Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := runChangelog(config, *session); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
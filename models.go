@@ -10,14 +10,26 @@ type Commit struct {
 	SHA           string
 	Message       string
 	Full          string
+	Body          string // Message body after the subject line
 	Date          time.Time
 	Author        string
+	AuthorEmail   string
 	IsMerge       bool
 	ParentCount   int
 	FilesChanged  []string
+	ScopedFiles   []string // Changed files inside the path scope, when the list is scoped
 	Insertions    int
 	Deletions     int
 	AlreadyApplied bool
+	Tickets       []string // Issue-tracker keys found in the message
+	Backports     []Backport // Targets this commit was picked to, from the ledger
+	Conventional  *ConventionalCommit // Parsed Conventional Commits message, nil if not conventional
+	PolicyViolations []string // Reasons the target's branch policies forbid this commit
+
+	// Lower-cased copies of the searched fields, see cacheSearchFields
+	lowerFull   string
+	lowerAuthor string
+	lowerFiles  []string
 }
 
 type ConflictFile struct {
@@ -37,8 +49,9 @@ type EditorOption struct {
 type CherryPicker struct {
 	currentBranch     string
 	authorName        string
-	selectedAuthor    string   // Author to filter commits by (defaults to authorName)
-	availableAuthors  []string
+	authorFilter      AuthorFilter // Whose commits are listed (defaults to authorName)
+	authorDraft       AuthorFilter // Filter being edited in author mode
+	availableAuthors  []authorOption
 	commits           []Commit
 	selected          map[string]bool
 	currentIndex      int
@@ -49,12 +62,29 @@ type CherryPicker struct {
 	detailView        bool
 	hideApplied       bool
 	rangeSelection    bool
-	rangeStart        int
-	rangeEnd          int
+	rangeAnchor       string // SHA of the commit the range selection started at
+	width             int  // terminal size from the last tea.WindowSizeMsg
+	height            int
+	listOffset        int  // first visible commit shown in the list pane
+	listRows          int  // lines the list pane had at the last render
+	previewScroll     int  // first preview line shown
+	hidePreviewPane   bool // live preview pane turned off with v
+	diffViewMode      bool // full-screen diff viewer open
+	diffViewCommit    *Commit
+	diffFiles         []diffFile
+	diffViewError     string
+	diffScroll        int          // first diff row shown
+	diffSideBySide    bool
+	diffToggled       map[int]bool // files collapsed or expanded by hand, by index
+	helpMode          bool   // help overlay shown with ?
+	helpKeyMode       string // mode whose keys the help lists
+	helpScroll        int
+	keymap            map[string][]keyBinding // bindings by mode, from keys in the config
 	conflictMode      bool
 	conflictCommit    string
 	conflictFiles     []ConflictFile
 	conflictResolved  bool
+	conflictDecision  string // "continue", "skip" or "abort" once the conflict was handled
 	editorMode        bool
 	availableEditors  []EditorOption
 	editorIndex       int
@@ -62,20 +92,69 @@ type CherryPicker struct {
 	executeRequested  bool
 	searchMode        bool
 	searchQuery       string
-	filteredCommits   []int  // indices of commits that match search
+	searchMatches     map[string]int // SHA -> score of the commits matching the search, nil if not searching
+	searchFilter      *CommitQuery // parsed search query, nil if it doesn't parse
+	searchError       string       // why the search query doesn't parse
+	searchHighlights  map[string][]int // SHA -> rune positions of the message matched by the search
+	paths             []string // path scope: only commits touching these paths or globs
+	pathMode          bool
+	pathInput         string
+	pathError         string
+	pickaxeMode       bool
+	pickaxeInput      string
+	pickaxeError      string
+	pickaxeTerm       string                   // active diff search, "" if none
+	pickaxeRegex      bool                     // pickaxeTerm is a regex (git log -G)
+	pickaxeMatches    map[string]pickaxeChange // SHA -> additions/removals of the term
 	previewMode       bool
 	previewCommit     *Commit
 	previewDiff       string
 	previewStats      string
 	branchMode        bool
 	branchSwitchType  string // "target" or "source"
-	availableBranches []string
+	availableBranches []BranchInfo
+	branchScope       string // "both", "local" or "remote"
 	authorMode           bool
 	authorIndex          int
 	authorSearchMode     bool
 	authorSearchQuery    string
 	filteredAuthors      []int  // indices of authors that match search
 	branchIndex          int
+	groupByTicket        bool
+	collapsedTickets     map[string]bool // ticket group -> collapsed
+	sessionID            string          // identifies this run to hooks
+	runSHAs              []string        // commits queued for the current run
+	runPosition          int             // next index into runSHAs
+	pickedSHAs           []string        // commits picked so far in the run
+	pickedTargets        map[string]string // source SHA -> commit it was picked as in this run
+	pickBases            map[string]string // source SHA -> HEAD right before it was picked
+	runEntries           []LedgerEntry     // outcome of every commit of the run, as recorded in the ledger
+	runPushed            bool              // the target was pushed in this run
+	runStartHead         string          // target HEAD before the run started
+	backportBranch       string          // branch the run picks onto in pull request mode
+	pullRequestURL       string          // pull request opened for the run's backport branch
+	finalVerifyDone      bool
+	verifyMode           bool
+	verifyCommit         string
+	verifyOutput         string
+	verifyDecision       string // "revert", "continue" or "abort"
+	typeFilter           map[string]bool // commit types to show; empty shows all
+	typeFilterMode       bool
+	typeIndex            int
+	policyOverride       bool // execute commits that violate the target's policies
+	policyBlocked        bool // execution was refused because of policy violations
+	protectConfirmMode   bool
+	protectConfirmInput  string
+	protectConfirmFailed bool
+	protectedConfirmed   bool // user typed the protected target's name
+	runRemoteHead        string   // remote target tip after the pull, "" if the remote has no such branch
+	pushPreviewMode      bool
+	pushPreview          []string // commits a push would publish, as --oneline
+	pushDecision         string   // "push" or "skip"
+	pushConfirmed        bool
+	skipPush             bool
+	forcePush            bool // the run rewrote pushed history, so publishing it needs a force push
+	forceConfirmed       bool // user confirmed the force push on the pre-push screen
 }
 
 type tickMsg time.Time
@@ -100,126 +179,57 @@ func (cp *CherryPicker) getSelectedSHAs() []string {
 	return shas
 }
 
-// toggleRangeSelection toggles range selection mode
-func (cp *CherryPicker) toggleRangeSelection() {
-	if !cp.rangeSelection {
-		// Start range selection
-		cp.rangeSelection = true
-		cp.rangeStart = cp.currentIndex
-		cp.rangeEnd = cp.currentIndex
-	} else {
-		// End range selection and select all commits in range
-		cp.selectRange()
-		cp.rangeSelection = false
-	}
-}
-
-// selectRange selects all commits in the current range
-func (cp *CherryPicker) selectRange() {
-	start := cp.rangeStart
-	end := cp.rangeEnd
-	
-	// Ensure start <= end
-	if start > end {
-		start, end = end, start
-	}
-	
-	// Select all commits in range (except already applied ones)
-	for i := start; i <= end && i < len(cp.commits); i++ {
-		if !cp.commits[i].AlreadyApplied {
-			cp.selected[cp.commits[i].SHA] = true
-		}
-	}
-}
-
-// updateRangeEnd updates the end of the range selection
-func (cp *CherryPicker) updateRangeEnd() {
-	if cp.rangeSelection {
-		cp.rangeEnd = cp.currentIndex
-	}
-}
-
-// isInRange checks if a commit index is within the current range selection
-func (cp *CherryPicker) isInRange(index int) bool {
-	if !cp.rangeSelection {
-		return false
-	}
-	
-	start := cp.rangeStart
-	end := cp.rangeEnd
-	
-	if start > end {
-		start, end = end, start
-	}
-	
-	return index >= start && index <= end
-}
-
-// toggleCommitOrder reverses the order of commits and adjusts the current index
+// toggleCommitOrder reverses the order of commits, keeping the cursor on the same commit
 func (cp *CherryPicker) toggleCommitOrder() {
-	// Reverse the commits slice
-	for i, j := 0, len(cp.commits)-1; i < j; i, j = i+1, j-1 {
-		cp.commits[i], cp.commits[j] = cp.commits[j], cp.commits[i]
-	}
-	
-	// Adjust current index to maintain position relative to the currently selected commit
-	cp.currentIndex = len(cp.commits) - 1 - cp.currentIndex
-	
-	// If we're in range selection mode, we need to adjust those indices too
-	if cp.rangeSelection {
-		cp.rangeStart = len(cp.commits) - 1 - cp.rangeStart
-		cp.rangeEnd = len(cp.commits) - 1 - cp.rangeEnd
-		
-		// Ensure rangeStart is still <= rangeEnd after reversal
-		if cp.rangeStart > cp.rangeEnd {
-			cp.rangeStart, cp.rangeEnd = cp.rangeEnd, cp.rangeStart
+	cp.keepCursor(func() {
+		for i, j := 0, len(cp.commits)-1; i < j; i, j = i+1, j-1 {
+			cp.commits[i], cp.commits[j] = cp.commits[j], cp.commits[i]
 		}
-	}
+	})
 	
 	// Toggle the reverse flag to track current state
 	cp.reverse = !cp.reverse
-	
-	// Always start cursor at the top after reversal
-	cp.currentIndex = 0
 }
 
-// toggleSearchMode enters or exits search mode
+// toggleSearchMode enters search mode, editing the kept search if any, or exits it and
+// clears the search
 func (cp *CherryPicker) toggleSearchMode() {
 	if !cp.searchMode {
-		// Enter search mode
 		cp.searchMode = true
-		cp.searchQuery = ""
 		cp.updateSearchResults()
 	} else {
-		// Exit search mode
 		cp.searchMode = false
-		cp.searchQuery = ""
-		cp.filteredCommits = nil
-		// Reset cursor to a valid position
-		if cp.currentIndex >= len(cp.commits) {
-			cp.currentIndex = len(cp.commits) - 1
-		}
-		if cp.currentIndex < 0 {
-			cp.currentIndex = 0
-		}
+		cp.keepCursor(cp.clearSearch)
 	}
 }
 
-// updateSearchResults filters commits based on search query
+// clearSearch drops the search filter
+func (cp *CherryPicker) clearSearch() {
+	cp.searchQuery = ""
+	cp.searchMatches = nil
+	cp.searchFilter = nil
+	cp.searchError = ""
+	cp.searchHighlights = nil
+}
+
+// updateSearchResults filters commits based on the search query language (see CommitQuery)
 func (cp *CherryPicker) updateSearchResults() {
-	cp.filteredCommits = nil
-	
-	if cp.searchQuery == "" {
-		// Empty search shows all commits
-		for i := range cp.commits {
-			cp.filteredCommits = append(cp.filteredCommits, i)
-		}
+	cp.searchMatches = make(map[string]int)
+	cp.searchError = ""
+	cp.searchHighlights = make(map[string][]int)
+	
+	query, err := parseCommitQuery(cp.searchQuery)
+	cp.searchFilter = query
+	if err != nil {
+		// Incomplete queries (e.g. an open quote) match nothing until fixed
+		cp.searchError = err.Error()
 	} else {
-		// Filter commits based on fuzzy matching
-		query := strings.ToLower(cp.searchQuery)
-		for i, commit := range cp.commits {
-			if cp.commitMatchesSearch(commit, query) {
-				cp.filteredCommits = append(cp.filteredCommits, i)
+		for _, commit := range cp.commits {
+			if ok, score, positions := query.Match(commit); ok {
+				cp.searchMatches[commit.SHA] = score
+				if len(positions) > 0 {
+					cp.searchHighlights[commit.SHA] = positions
+				}
 			}
 		}
 	}
@@ -228,114 +238,27 @@ func (cp *CherryPicker) updateSearchResults() {
 	cp.currentIndex = 0
 }
 
-// commitMatchesSearch checks if a commit matches the search query
-func (cp *CherryPicker) commitMatchesSearch(commit Commit, query string) bool {
-	// Search in commit message
-	if strings.Contains(strings.ToLower(commit.Message), query) {
-		return true
-	}
-	
-	// Search in full commit line
-	if strings.Contains(strings.ToLower(commit.Full), query) {
-		return true
-	}
-	
-	// Search in SHA
-	if strings.Contains(strings.ToLower(commit.SHA), query) {
-		return true
-	}
-	
-	// Search in author name
-	if strings.Contains(strings.ToLower(commit.Author), query) {
-		return true
-	}
-	
-	// Search in changed files
-	for _, file := range commit.FilesChanged {
-		if strings.Contains(strings.ToLower(file), query) {
-			return true
-		}
-	}
-	
-	return false
-}
-
-// getVisibleCommits returns the commits that should be displayed (filtered or all)
-func (cp *CherryPicker) getVisibleCommits() []Commit {
-	var baseCommits []Commit
-	
-	if cp.searchMode && len(cp.filteredCommits) > 0 {
-		// Get filtered commits
-		for _, index := range cp.filteredCommits {
-			if index < len(cp.commits) {
-				baseCommits = append(baseCommits, cp.commits[index])
-			}
-		}
-	} else {
-		// Get all commits
-		baseCommits = cp.commits
-	}
-	
-	// Filter out already applied commits if hideApplied is enabled
-	if cp.hideApplied {
-		var visible []Commit
-		for _, commit := range baseCommits {
-			if !commit.AlreadyApplied {
-				visible = append(visible, commit)
-			}
-		}
-		return visible
-	}
-	
-	return baseCommits
-}
-
-// getCurrentCommit returns the currently selected commit (accounting for search filter)
-func (cp *CherryPicker) getCurrentCommit() *Commit {
-	if cp.searchMode && len(cp.filteredCommits) > 0 {
-		if cp.currentIndex < len(cp.filteredCommits) {
-			realIndex := cp.filteredCommits[cp.currentIndex]
-			if realIndex < len(cp.commits) {
-				return &cp.commits[realIndex]
-			}
-		}
-	} else {
-		if cp.currentIndex < len(cp.commits) {
-			return &cp.commits[cp.currentIndex]
-		}
-	}
-	return nil
-}
-
-// getMaxIndex returns the maximum valid index for navigation
-func (cp *CherryPicker) getMaxIndex() int {
-	if cp.searchMode {
-		return len(cp.filteredCommits) - 1
-	}
-	return len(cp.commits) - 1
-}
-
 // togglePreviewMode enters or exits preview mode for the current commit
 func (cp *CherryPicker) togglePreviewMode() {
 	if !cp.previewMode {
-		// Enter preview mode
+		// Enter preview mode, keeping the live pane's scroll position
 		cp.previewMode = true
-		commit := cp.getCurrentCommit()
-		if commit != nil {
-			cp.loadPreviewData(commit)
-		}
+		cp.updatePreview()
 	} else {
-		// Exit preview mode
+		// Exit preview mode; the live pane keeps showing the commit
 		cp.previewMode = false
-		cp.previewCommit = nil
-		cp.previewDiff = ""
-		cp.previewStats = ""
+		if cp.previewPlacement() == previewPaneOff {
+			cp.previewCommit = nil
+			cp.previewDiff = ""
+			cp.previewStats = ""
+		}
 	}
 }
 
 // loadPreviewData fetches detailed information for the given commit
 func (cp *CherryPicker) loadPreviewData(commit *Commit) {
 	cp.previewCommit = commit
+	cp.previewScroll = 0
 	
 	// Get the full diff
 	if diff, err := cp.getCommitDiff(commit.SHA); err == nil {
@@ -345,7 +268,7 @@ func (cp *CherryPicker) loadPreviewData(commit *Commit) {
 	}
 	
 	// Get detailed stats
-	if stats, err := cp.getCommitStats(commit.SHA); err == nil {
+	if stats, err := cp.getScopedCommitStats(commit.SHA); err == nil {
 		cp.previewStats = stats
 	} else {
 		cp.previewStats = "Error loading stats: " + err.Error()
@@ -354,9 +277,11 @@ func (cp *CherryPicker) loadPreviewData(commit *Commit) {
 
 // updatePreview updates the preview when cursor moves to a different commit
 func (cp *CherryPicker) updatePreview() {
-	if cp.previewMode {
+	if cp.previewMode || cp.previewPlacement() != previewPaneOff {
 		commit := cp.getCurrentCommit()
-		if commit != nil && (cp.previewCommit == nil || cp.previewCommit.SHA != commit.SHA) {
+		if commit == nil {
+			cp.previewCommit = nil
+		} else if cp.previewCommit == nil || cp.previewCommit.SHA != commit.SHA {
 			cp.loadPreviewData(commit)
 		}
 	}
@@ -462,7 +387,7 @@ func (cp *CherryPicker) loadAvailableBranches() {
 		
 		// Find and select current branch
 		for i, branch := range cp.availableBranches {
-			if branch == currentBranch {
+			if branch.Name == currentBranch {
 				cp.branchIndex = i
 				break
 			}
@@ -474,20 +399,13 @@ func (cp *CherryPicker) loadAvailableBranches() {
 func (cp *CherryPicker) enterAuthorMode() {
 	cp.authorMode = true
 	cp.authorIndex = 0
+	cp.authorDraft = cp.authorFilter
 	
 	// Load available authors if not already loaded
 	if len(cp.availableAuthors) == 0 {
 		if err := cp.getAvailableAuthors(); err != nil {
-			// Handle error - for now just use current author
-			cp.availableAuthors = []string{cp.authorName}
-		}
-	}
-	
-	// Find current selected author index
-	for i, author := range cp.availableAuthors {
-		if author == cp.selectedAuthor {
-			cp.authorIndex = i
-			break
+			// Handle error - for now just offer all authors, teams and the current author
+			cp.availableAuthors = cp.authorOptions([]authorOption{{Kind: authorOptionAuthor, Name: cp.authorName}})
 		}
 	}
 }
@@ -496,15 +414,22 @@ func (cp *CherryPicker) enterAuthorMode() {
 func (cp *CherryPicker) exitAuthorMode() {
 	cp.authorMode = false
 	cp.authorIndex = 0
+	cp.authorSearchMode = false
+	cp.authorSearchQuery = ""
+	cp.filteredAuthors = nil
 }
 
-// selectAuthor applies the selected author and reloads commits
-func (cp *CherryPicker) selectAuthor() error {
-	if cp.authorIndex >= len(cp.availableAuthors) {
-		return fmt.Errorf("invalid author selection")
+// toggleAuthor adds or removes the author, team or all-authors entry under the cursor
+func (cp *CherryPicker) toggleAuthor() {
+	visibleAuthors := cp.getVisibleAuthors()
+	if cp.authorIndex < len(visibleAuthors) {
+		cp.authorDraft = cp.authorDraft.toggle(visibleAuthors[cp.authorIndex])
 	}
-	
-	cp.selectedAuthor = cp.availableAuthors[cp.authorIndex]
+}
+
+// applyAuthors applies the edited author filter and reloads commits
+func (cp *CherryPicker) applyAuthors() error {
+	cp.authorFilter = cp.authorDraft
 	cp.exitAuthorMode()
 	
 	// Reload commits with new author filter
@@ -517,10 +442,17 @@ func (cp *CherryPicker) selectBranch() error {
 		return fmt.Errorf("invalid branch selection")
 	}
 	
-	selectedBranch := cp.availableBranches[cp.branchIndex]
+	selectedBranch := cp.availableBranches[cp.branchIndex].Name
 	
 	// Update configuration
 	if cp.branchSwitchType == "target" {
+		// Pick onto a local branch, tracking the remote one if needed
+		if _, err := trackRemoteBranch(cp.targetRemote(), selectedBranch); err != nil {
+			return err
+		}
+		if selectedBranch != cp.config.Git.TargetBranch {
+			cp.targetChanged()
+		}
 		cp.config.Git.TargetBranch = selectedBranch
 	} else {
 		cp.config.Git.SourceBranch = selectedBranch
@@ -533,14 +465,21 @@ func (cp *CherryPicker) selectBranch() error {
 	return cp.reloadCommits()
 }
 
+// targetChanged forgets decisions that were made for the previous target branch
+func (cp *CherryPicker) targetChanged() {
+	cp.policyOverride = false
+	cp.policyBlocked = false
+	cp.protectedConfirmed = false
+}
+
 // reloadCommits reloads the commit list with current configuration
 func (cp *CherryPicker) reloadCommits() error {
 	// Clear current state
 	cp.commits = nil
 	cp.selected = make(map[string]bool)
-	cp.filteredCommits = nil
-	cp.searchQuery = ""
+	cp.clearSearch()
 	cp.searchMode = false
+	cp.rangeSelection = false
 	cp.previewMode = false
 	cp.previewCommit = nil
 	
@@ -549,7 +488,19 @@ func (cp *CherryPicker) reloadCommits() error {
 		return err
 	}
 	
-	return cp.getUniqueCommits()
+	if err := cp.getUniqueCommits(); err != nil {
+		return err
+	}
+	
+	// Repeat the diff search over the new commits
+	if cp.pickaxeActive() {
+		search := cp.pickaxeTerm
+		if cp.pickaxeRegex {
+			search = "/" + search + "/"
+		}
+		return cp.runPickaxe(search)
+	}
+	return nil
 }
 
 // toggleAuthorSearchMode enters or exits author search mode
@@ -587,7 +538,7 @@ func (cp *CherryPicker) updateAuthorSearchResults() {
 		// Filter authors based on search query
 		query := strings.ToLower(cp.authorSearchQuery)
 		for i, author := range cp.availableAuthors {
-			if strings.Contains(strings.ToLower(author), query) {
+			if strings.Contains(strings.ToLower(author.label()), query) {
 				cp.filteredAuthors = append(cp.filteredAuthors, i)
 			}
 		}
@@ -598,12 +549,12 @@ func (cp *CherryPicker) updateAuthorSearchResults() {
 }
 
 // getVisibleAuthors returns the authors that should be displayed (filtered or all)
-func (cp *CherryPicker) getVisibleAuthors() []string {
-	if !cp.authorSearchMode || len(cp.filteredAuthors) == 0 {
+func (cp *CherryPicker) getVisibleAuthors() []authorOption {
+	if cp.filteredAuthors == nil {
 		return cp.availableAuthors
 	}
 	
-	var visible []string
+	var visible []authorOption
 	for _, index := range cp.filteredAuthors {
 		if index < len(cp.availableAuthors) {
 			visible = append(visible, cp.availableAuthors[index])
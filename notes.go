@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// backportNote is one line of a note under refs/notes/<ref>, stored as JSON
+type backportNote struct {
+	Target string    `json:"target"`
+	SHA    string    `json:"sha"`
+	User   string    `json:"user"`
+	Time   time.Time `json:"time"`
+}
+
+// notesRef returns the full notes ref, e.g. refs/notes/cherry-picker
+func (cp *CherryPicker) notesRef() string {
+	ref := cp.config.Notes.Ref
+	if ref == "" {
+		ref = "cherry-picker"
+	}
+	if strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/notes/" + ref
+}
+
+// remoteNotesRef is where the remote's notes are fetched before merging them locally
+func (cp *CherryPicker) remoteNotesRef() string {
+	return "refs/notes/remotes/" + cp.targetRemote() + "/" + strings.TrimPrefix(cp.notesRef(), "refs/notes/")
+}
+
+// writeBackportNote appends a note on the source commit recording where it landed. Pull
+// request runs write none: their picks are on the backport branch, and may never reach the
+// target.
+func (cp *CherryPicker) writeBackportNote(sourceSHA, targetSHA string) {
+	if !cp.config.Notes.Enabled || targetSHA == "" || cp.backportBranch != "" {
+		return
+	}
+
+	data, err := json.Marshal(backportNote{
+		Target: cp.config.Git.TargetBranch,
+		SHA:    targetSHA,
+		User:   cp.authorName,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	cmd := exec.Command("git", "notes", "--ref="+cp.notesRef(), "append", "-m", string(data), sourceSHA)
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("⚠️  Could not write backport note for %s: %v\n", sourceSHA, err)
+	}
+}
+
+// removeBackportNote drops the line recording targetSHA from the source commit's note, and
+// the whole note once nothing else is left in it, so a reverted pick isn't published
+func (cp *CherryPicker) removeBackportNote(sourceSHA, targetSHA string) {
+	if !cp.config.Notes.Enabled || targetSHA == "" {
+		return
+	}
+
+	output, err := exec.Command("git", "notes", "--ref="+cp.notesRef(), "show", sourceSHA).Output()
+	if err != nil {
+		return
+	}
+
+	var kept []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var note backportNote
+		if json.Unmarshal([]byte(line), &note) == nil && note.SHA == targetSHA {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	cmd := exec.Command("git", "notes", "--ref="+cp.notesRef(), "remove", sourceSHA)
+	if len(kept) > 0 {
+		cmd = exec.Command("git", "notes", "--ref="+cp.notesRef(), "add", "-f", "-m", strings.Join(kept, "\n"), sourceSHA)
+	}
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("⚠️  Could not remove backport note for %s: %v\n", sourceSHA, err)
+	}
+}
+
+// fetchNotes fetches the remote notes ref and merges it into the local one
+func (cp *CherryPicker) fetchNotes() {
+	if !cp.config.Notes.Enabled || !cp.config.Notes.Sync {
+		return
+	}
+
+	remote := cp.targetRemote()
+	refspec := "+" + cp.notesRef() + ":" + cp.remoteNotesRef()
+	if err := exec.Command("git", "fetch", remote, refspec).Run(); err != nil {
+		// The remote may simply not have any notes yet
+		return
+	}
+
+	// cat_sort_uniq keeps every line from both sides, which suits our one-JSON-object-per-line notes
+	merge := exec.Command("git", "notes", "--ref="+cp.notesRef(), "merge", "-s", "cat_sort_uniq", cp.remoteNotesRef())
+	if err := merge.Run(); err != nil {
+		fmt.Printf("⚠️  Could not merge backport notes from %s: %v\n", remote, err)
+	}
+}
+
+// pushNotes pushes the local notes ref so teammates can see the backports
+func (cp *CherryPicker) pushNotes() {
+	if !cp.config.Notes.Enabled || !cp.config.Notes.Sync {
+		return
+	}
+
+	remote := cp.targetRemote()
+	if err := exec.Command("git", "push", remote, cp.notesRef()).Run(); err != nil {
+		fmt.Printf("⚠️  Could not push backport notes to %s: %v\n", remote, err)
+	}
+}
+
+// loadBackportNotes reads every backport note, keyed by full source SHA
+func (cp *CherryPicker) loadBackportNotes() map[string][]Backport {
+	notes := make(map[string][]Backport)
+	if !cp.config.Notes.Enabled {
+		return notes
+	}
+
+	output, err := exec.Command("git", "notes", "--ref="+cp.notesRef(), "list").Output()
+	if err != nil {
+		return notes
+	}
+
+	var noteObjects, sourceSHAs []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		noteObjects = append(noteObjects, fields[0])
+		sourceSHAs = append(sourceSHAs, fields[1])
+	}
+	if len(noteObjects) == 0 {
+		return notes
+	}
+
+	// Read every note blob in a single git process
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(strings.Join(noteObjects, "\n") + "\n")
+	output, err = cmd.Output()
+	if err != nil {
+		return notes
+	}
+
+	for i, content := range parseCatFileBatch(output) {
+		if i < len(sourceSHAs) && content != "" {
+			notes[sourceSHAs[i]] = parseBackportNote(content)
+		}
+	}
+	return notes
+}
+
+// parseCatFileBatch splits `git cat-file --batch` output into the contents of each requested
+// object, in request order, with "" for missing objects
+func parseCatFileBatch(output []byte) []string {
+	var contents []string
+	for len(output) > 0 {
+		end := bytes.IndexByte(output, '\n')
+		if end < 0 {
+			break
+		}
+		header := strings.Fields(string(output[:end]))
+		output = output[end+1:]
+
+		// "<object> missing" has no content
+		if len(header) != 3 {
+			contents = append(contents, "")
+			continue
+		}
+		size, err := strconv.Atoi(header[2])
+		if err != nil || size > len(output) {
+			break
+		}
+		contents = append(contents, string(output[:size]))
+		output = bytes.TrimPrefix(output[size:], []byte("\n"))
+	}
+	return contents
+}
+
+// parseBackportNote parses the JSON lines of a note, ignoring lines it doesn't understand
+func parseBackportNote(content string) []Backport {
+	var backports []Backport
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var note backportNote
+		if err := json.Unmarshal([]byte(line), &note); err != nil || note.Target == "" {
+			continue
+		}
+		backports = append(backports, Backport{
+			Target:    note.Target,
+			TargetSHA: note.SHA,
+			User:      note.User,
+			Time:      note.Time,
+		})
+	}
+	return backports
+}
+
+// notedBackportsOf returns the noted backports of a possibly abbreviated SHA
+func notedBackportsOf(notes map[string][]Backport, sha string) []Backport {
+	if backports, ok := notes[sha]; ok {
+		return backports
+	}
+	for fullSHA, backports := range notes {
+		if sameCommit(fullSHA, sha) {
+			return backports
+		}
+	}
+	return nil
+}
+
+// mergeBackports combines backports from several sources, dropping duplicates
+func mergeBackports(lists ...[]Backport) []Backport {
+	var merged []Backport
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, backport := range list {
+			key := backport.Target + " " + backport.TargetSHA
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, backport)
+		}
+	}
+	return merged
+}
+
+// isNotedOnTarget reports whether a note says the commit already landed on the current target.
+// Notes are only trusted when their target commit is known locally and still on the target
+// branch; picks made elsewhere show up once the target has been fetched.
+func (cp *CherryPicker) isNotedOnTarget(backports []Backport, targetRef string) bool {
+	for _, backport := range backports {
+		if backport.Target != cp.config.Git.TargetBranch {
+			continue
+		}
+		if err := exec.Command("git", "cat-file", "-e", backport.TargetSHA+"^{commit}").Run(); err != nil {
+			// Not fetched yet, or the pick was reverted or never pushed
+			continue
+		}
+		if targetRef != "" && exec.Command("git", "merge-base", "--is-ancestor", backport.TargetSHA, targetRef).Run() == nil {
+			return true
+		}
+	}
+	return false
+}
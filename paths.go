@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pathList is a repeatable command line flag collecting paths or globs
+type pathList []string
+
+func (p *pathList) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *pathList) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// pathspec converts a path or glob to a git pathspec relative to the repository root.
+// Globs use git's glob magic, so services/billing/** matches everything below that directory
+// and * doesn't cross directories. Pathspecs that already use magic (":(...)") are kept.
+func pathspec(path string) string {
+	if strings.HasPrefix(path, ":") {
+		return path
+	}
+	if strings.ContainsAny(path, "*?[") {
+		return ":(top,glob)" + path
+	}
+	return ":(top)" + path
+}
+
+// cleanPaths trims the paths and drops empty ones
+func cleanPaths(paths []string) []string {
+	var cleaned []string
+	for _, path := range paths {
+		if path = strings.TrimSpace(path); path != "" {
+			cleaned = append(cleaned, path)
+		}
+	}
+	return cleaned
+}
+
+// pathArgs returns the arguments limiting git log, show and diff to the path scope, or nil
+// if the view isn't scoped
+func (cp *CherryPicker) pathArgs() []string {
+	if len(cp.paths) == 0 {
+		return nil
+	}
+	args := []string{"--"}
+	for _, path := range cp.paths {
+		args = append(args, pathspec(path))
+	}
+	return args
+}
+
+// pathsLabel describes the path scope for the header
+func (cp *CherryPicker) pathsLabel() string {
+	return strings.Join(cp.paths, ", ")
+}
+
+// numstatTotals sums git show --numstat for a commit, limited to the given pathspecs if any
+func numstatTotals(sha string, specs ...string) (files, added, removed int, err error) {
+	args := []string{"show", "--numstat", "--format=", sha}
+	if len(specs) > 0 {
+		args = append(append(args, "--"), specs...)
+	}
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 3 {
+			continue
+		}
+		files++
+		// Binary files show "-" and count as changed files only
+		var a, r int
+		fmt.Sscanf(parts[0], "%d", &a)
+		fmt.Sscanf(parts[1], "%d", &r)
+		added += a
+		removed += r
+	}
+	return files, added, removed, nil
+}
+
+// pathStats returns the per-path diffstat of a commit in a scoped view, and how many of its
+// changed files are outside the scope
+func (cp *CherryPicker) pathStats(sha string) string {
+	if len(cp.paths) == 0 {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString("🗂️  Path scope:\n")
+	for _, path := range cp.paths {
+		files, added, removed, err := numstatTotals(sha, pathspec(path))
+		if err != nil {
+			continue
+		}
+		s.WriteString(fmt.Sprintf("  %s: %d file(s), +%d -%d\n", path, files, added, removed))
+	}
+
+	total, _, _, err := numstatTotals(sha)
+	inScope, _, _, scopedErr := numstatTotals(sha, cp.pathArgs()[1:]...)
+	if err == nil && scopedErr == nil && total > inScope {
+		s.WriteString(fmt.Sprintf("  (%d other file(s) outside the path scope are hidden)\n", total-inScope))
+	}
+	s.WriteString("\n")
+	return s.String()
+}
+
+// enterPathMode opens the path scope prompt, prefilled with the current paths
+func (cp *CherryPicker) enterPathMode() {
+	cp.pathMode = true
+	cp.pathError = ""
+	cp.pathInput = strings.Join(cp.paths, " ")
+}
+
+// exitPathMode closes the path scope prompt
+func (cp *CherryPicker) exitPathMode() {
+	cp.pathMode = false
+	cp.pathInput = ""
+}
+
+// applyPaths scopes the view to the entered paths and reloads commits; no paths clears the scope
+func (cp *CherryPicker) applyPaths() error {
+	previous := cp.paths
+	cp.paths = cleanPaths(strings.Fields(cp.pathInput))
+	if err := cp.reloadCommits(); err != nil {
+		// Bring the previous list back
+		cp.paths = previous
+		cp.reloadCommits()
+		return err
+	}
+	cp.exitPathMode()
+	return nil
+}
+
+// handlePathInput handles typing the path scope; ENTER applies it, ESC cancels
+func (cp *CherryPicker) handlePathInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModePaths, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "close":
+		cp.exitPathMode()
+		return cp, nil
+	case "apply":
+		if err := cp.applyPaths(); err != nil {
+			cp.pathError = err.Error()
+		}
+		return cp, nil
+	case "delete":
+		if len(cp.pathInput) > 0 {
+			cp.pathInput = cp.pathInput[:len(cp.pathInput)-1]
+		}
+		return cp, nil
+	case "help":
+		cp.openHelp()
+		return cp, nil
+	}
+
+	if len(msg.String()) == 1 && msg.String() >= " " && msg.String() <= "~" {
+		cp.pathInput += msg.String()
+	}
+	return cp, nil
+}
+
+// renderPathPrompt renders the path scope prompt shown above the commit list
+func (cp *CherryPicker) renderPathPrompt() string {
+	var s strings.Builder
+	s.WriteString("🗂️  Paths: " + cp.pathInput + "█\n")
+	if cp.pathError != "" {
+		s.WriteString("⚠️  " + cp.pathError + "\n")
+	}
+	apply := cp.keyFor(keyModePaths, "apply")
+	s.WriteString(fmt.Sprintf("(space-separated paths or globs, e.g. services/billing/**; %s=apply, empty %s=clear, %s=cancel, %s=help)\n\n",
+		apply, apply, cp.keyFor(keyModePaths, "close"), cp.keyFor(keyModePaths, "help")))
+	return s.String()
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pickaxeChange counts how often a diff search term was added and removed by a commit
+type pickaxeChange struct {
+	Added   int
+	Removed int
+}
+
+// parsePickaxe splits a diff search into its term and whether it is a /regex/ (git log -G)
+// rather than a literal string (git log -S)
+func parsePickaxe(input string) (string, bool) {
+	input = strings.TrimSpace(input)
+	if len(input) >= 2 && strings.HasPrefix(input, "/") && strings.HasSuffix(input, "/") {
+		return input[1 : len(input)-1], true
+	}
+	return input, false
+}
+
+// pickaxeActive reports whether the list is limited to a diff search
+func (cp *CherryPicker) pickaxeActive() bool {
+	return cp.pickaxeTerm != ""
+}
+
+// pickaxeLabel describes the active diff search, e.g. -S "parseConfig"
+func (cp *CherryPicker) pickaxeLabel() string {
+	if cp.pickaxeRegex {
+		return fmt.Sprintf("-G /%s/", cp.pickaxeTerm)
+	}
+	return fmt.Sprintf("-S %q", cp.pickaxeTerm)
+}
+
+// pickaxePattern returns the regex matching the diff search term in diff lines
+func (cp *CherryPicker) pickaxePattern() *regexp.Regexp {
+	re, err := compilePickaxe(cp.pickaxeTerm, cp.pickaxeRegex)
+	if err != nil {
+		return regexp.MustCompile(regexp.QuoteMeta(cp.pickaxeTerm))
+	}
+	return re
+}
+
+// compilePickaxe compiles a diff search term: a Go regexp for a /regex/, a literal otherwise
+func compilePickaxe(term string, regex bool) (*regexp.Regexp, error) {
+	if !regex {
+		return regexp.Compile(regexp.QuoteMeta(term))
+	}
+	re, err := regexp.Compile(term)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex /%s/: %v", term, err)
+	}
+	return re, nil
+}
+
+// pickaxeCommitMarker starts each commit in the diff search log
+const pickaxeCommitMarker = "\x00commit "
+
+// runPickaxe finds the source commits whose diffs add or remove a string (git log -S) or
+// touch lines matching a regex, and counts the additions and removals of each. Regexes are
+// matched here rather than with git log -G, so the list, the counts and the highlighted hunks
+// all use Go regexp syntax. Everything comes from a single git log. An empty search clears
+// it; a failed one keeps the previous search.
+func (cp *CherryPicker) runPickaxe(input string) error {
+	term, regex := parsePickaxe(input)
+	if term == "" {
+		cp.pickaxeTerm, cp.pickaxeRegex = "", false
+		cp.pickaxeMatches = nil
+		return nil
+	}
+	pattern, err := compilePickaxe(term, regex)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := cp.sourceRevisions()
+	if err != nil {
+		return err
+	}
+	args := []string{"log", "-p", "-U0", "--format=%x00commit %H"}
+	if !regex {
+		args = append(args, "-S"+term)
+	}
+	args = append(append(args, revisions...), cp.pathArgs()...)
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return fmt.Errorf("diff search failed: %v", err)
+	}
+
+	cp.pickaxeTerm, cp.pickaxeRegex = term, regex
+	cp.pickaxeMatches = make(map[string]pickaxeChange)
+	for full, change := range countPickaxeChanges(string(output), pattern) {
+		// A regex search keeps the commits with a matching changed line, like git log -G
+		if regex && change.Added+change.Removed == 0 {
+			continue
+		}
+		for _, commit := range cp.commits {
+			if sameCommit(commit.SHA, full) {
+				cp.pickaxeMatches[commit.SHA] = change
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// countPickaxeChanges counts the matches of pattern on added and removed lines of each
+// commit in a git log -p -U0 output, keyed by full SHA
+func countPickaxeChanges(log string, pattern *regexp.Regexp) map[string]pickaxeChange {
+	changes := make(map[string]pickaxeChange)
+	sha := ""
+	for _, line := range strings.Split(log, "\n") {
+		if strings.HasPrefix(line, pickaxeCommitMarker) {
+			sha = strings.TrimSpace(strings.TrimPrefix(line, pickaxeCommitMarker))
+			changes[sha] = pickaxeChange{}
+			continue
+		}
+		if sha == "" {
+			continue
+		}
+		change := changes[sha]
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			change.Added += len(pattern.FindAllStringIndex(line[1:], -1))
+		case strings.HasPrefix(line, "-"):
+			change.Removed += len(pattern.FindAllStringIndex(line[1:], -1))
+		}
+		changes[sha] = change
+	}
+	return changes
+}
+
+// filterCommitsByPickaxe keeps only the commits found by the diff search
+func (cp *CherryPicker) filterCommitsByPickaxe(commits []Commit) []Commit {
+	var filtered []Commit
+	for _, commit := range commits {
+		if _, ok := cp.pickaxeMatches[commit.SHA]; ok {
+			filtered = append(filtered, commit)
+		}
+	}
+	return filtered
+}
+
+// pickaxeMarker shows how often a listed commit added and removed the searched string
+func (cp *CherryPicker) pickaxeMarker(commit Commit) string {
+	change, ok := cp.pickaxeMatches[commit.SHA]
+	if !cp.pickaxeActive() || !ok {
+		return ""
+	}
+	return fmt.Sprintf(" 🔎 +%d -%d", change.Added, change.Removed)
+}
+
+// pickaxeHunks returns the file headers and hunks of a diff whose changed lines match the
+// diff search, with the matches highlighted
+func (cp *CherryPicker) pickaxeHunks(diff string) []string {
+	if !cp.pickaxeActive() {
+		return nil
+	}
+	pattern := cp.pickaxePattern()
+
+	var result, fileHeader, hunk []string
+	hunkMatches := false
+	flush := func() {
+		if hunkMatches {
+			result = append(result, fileHeader...)
+			result = append(result, hunk...)
+			fileHeader = nil
+		}
+		hunk = nil
+		hunkMatches = false
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			flush()
+			fileHeader = []string{line}
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			hunk = []string{line}
+		case hunk == nil:
+			// Commit header, stats and file metadata before the first hunk
+			if fileHeader != nil && (strings.HasPrefix(line, "+++") || strings.HasPrefix(line, "---")) {
+				fileHeader = append(fileHeader, line)
+			}
+		default:
+			if (strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-")) && pattern.MatchString(line[1:]) {
+				hunkMatches = true
+				line = line[:1] + pattern.ReplaceAllStringFunc(line[1:], func(match string) string {
+					return highlightOn + match + highlightOff
+				})
+			}
+			hunk = append(hunk, line)
+		}
+	}
+	flush()
+	return result
+}
+
+// enterPickaxeMode opens the diff search prompt, prefilled with the active search
+func (cp *CherryPicker) enterPickaxeMode() {
+	cp.pickaxeMode = true
+	cp.pickaxeError = ""
+	cp.pickaxeInput = ""
+	if cp.pickaxeActive() {
+		cp.pickaxeInput = cp.pickaxeTerm
+		if cp.pickaxeRegex {
+			cp.pickaxeInput = "/" + cp.pickaxeTerm + "/"
+		}
+	}
+}
+
+// exitPickaxeMode closes the diff search prompt
+func (cp *CherryPicker) exitPickaxeMode() {
+	cp.pickaxeMode = false
+	cp.pickaxeInput = ""
+}
+
+// handlePickaxeInput handles typing a diff search; ENTER runs it, an empty search clears it
+func (cp *CherryPicker) handlePickaxeInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModePickaxe, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "close":
+		cp.exitPickaxeMode()
+		return cp, nil
+	case "search":
+		var err error
+		cp.keepCursor(func() { err = cp.runPickaxe(cp.pickaxeInput) })
+		if err != nil {
+			cp.pickaxeError = err.Error()
+			return cp, nil
+		}
+		cp.exitPickaxeMode()
+		cp.updatePreview()
+		return cp, nil
+	case "delete":
+		if len(cp.pickaxeInput) > 0 {
+			cp.pickaxeInput = cp.pickaxeInput[:len(cp.pickaxeInput)-1]
+		}
+		return cp, nil
+	case "help":
+		cp.openHelp()
+		return cp, nil
+	}
+
+	if len(msg.String()) == 1 && msg.String() >= " " && msg.String() <= "~" {
+		cp.pickaxeInput += msg.String()
+	}
+	return cp, nil
+}
+
+// renderPickaxePrompt renders the diff search prompt shown above the commit list
+func (cp *CherryPicker) renderPickaxePrompt() string {
+	var s strings.Builder
+	s.WriteString("🔎 Diff search: " + cp.pickaxeInput + "█\n")
+	if cp.pickaxeError != "" {
+		s.WriteString("⚠️  " + cp.pickaxeError + "\n")
+	}
+	search := cp.keyFor(keyModePickaxe, "search")
+	s.WriteString(fmt.Sprintf("(string = git log -S, /regex/ = Go regexp on changed lines; %s=search, empty %s=clear, %s=cancel, %s=help)\n\n",
+		search, search, cp.keyFor(keyModePickaxe, "close"), cp.keyFor(keyModePickaxe, "help")))
+	return s.String()
+}
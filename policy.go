@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// validatePolicies checks that every branch policy has a target pattern and a valid regex
+func validatePolicies(policies []BranchPolicy, ticketPattern string) error {
+	for i, policy := range policies {
+		if policy.Target == "" {
+			return fmt.Errorf("policy %d has no target pattern", i+1)
+		}
+		if policy.RequireTicket && ticketPattern == "" {
+			return fmt.Errorf("policy for %s requires a ticket but no tickets.pattern is configured", policy.Target)
+		}
+		if _, err := path.Match(policy.Target, ""); err != nil {
+			return fmt.Errorf("invalid policy target %q: %v", policy.Target, err)
+		}
+		if policy.AllowPattern != "" {
+			if _, err := regexp.Compile(policy.AllowPattern); err != nil {
+				return fmt.Errorf("invalid allow_pattern %q for %s: %v", policy.AllowPattern, policy.Target, err)
+			}
+		}
+	}
+	return nil
+}
+
+// targetPolicies returns the policies whose target pattern matches the current target branch
+func (cp *CherryPicker) targetPolicies() []BranchPolicy {
+	var policies []BranchPolicy
+	for _, policy := range cp.config.Policies {
+		if matchesBranchPattern([]string{policy.Target}, cp.config.Git.TargetBranch) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+// checkPolicies returns the reasons a commit may not be picked to the current target
+func (cp *CherryPicker) checkPolicies(commit Commit) []string {
+	var violations []string
+	for _, policy := range cp.targetPolicies() {
+		if policy.AllowPattern != "" {
+			re, err := regexp.Compile(policy.AllowPattern)
+			message := commit.Message + "\n" + commit.Body
+			if err == nil && !re.MatchString(message) {
+				violations = append(violations, fmt.Sprintf("message doesn't match %s", policy.AllowPattern))
+			}
+		}
+		if policy.NoMerges && commit.IsMerge {
+			violations = append(violations, "merge commits are not allowed")
+		}
+		if policy.MaxDiffLines > 0 && commit.Insertions+commit.Deletions > policy.MaxDiffLines {
+			violations = append(violations, fmt.Sprintf("diff of %d lines exceeds %d",
+				commit.Insertions+commit.Deletions, policy.MaxDiffLines))
+		}
+		if policy.RequireTicket && len(commit.Tickets) == 0 {
+			violations = append(violations, "no ticket reference")
+		}
+		for _, file := range commit.FilesChanged {
+			if blocked := matchBlockedPath(policy.BlockedPaths, file); blocked != "" {
+				violations = append(violations, fmt.Sprintf("touches blocked path %s (%s)", blocked, file))
+				break
+			}
+		}
+	}
+	return violations
+}
+
+// matchBlockedPath returns the blocked path pattern a file falls under, or "".
+// Patterns ending in "/" match directories; others are globs on the full path.
+func matchBlockedPath(patterns []string, file string) string {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			if strings.HasPrefix(file, pattern) || strings.Contains(file, "/"+pattern) {
+				return pattern
+			}
+			continue
+		}
+		if matched, err := path.Match(pattern, file); err == nil && matched {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// annotatePolicyViolations checks every loaded commit against the target's policies
+func (cp *CherryPicker) annotatePolicyViolations() {
+	for i := range cp.commits {
+		cp.commits[i].PolicyViolations = cp.checkPolicies(cp.commits[i])
+	}
+}
+
+// selectedViolations returns the selected commits that violate the target's policies
+func (cp *CherryPicker) selectedViolations() []Commit {
+	var violating []Commit
+	for _, commit := range cp.getSelectedCommits() {
+		if len(commit.PolicyViolations) > 0 {
+			violating = append(violating, commit)
+		}
+	}
+	return violating
+}
+
+// togglePolicyOverride allows or disallows executing commits that violate the policies
+func (cp *CherryPicker) togglePolicyOverride() {
+	cp.policyOverride = !cp.policyOverride
+	cp.policyBlocked = false
+}
+
+// enforcePolicies refuses a run containing policy violations unless the user overrode them
+func (cp *CherryPicker) enforcePolicies(shas []string) error {
+	var violating []string
+	for _, commit := range cp.commitsForSHAs(shas) {
+		if len(commit.PolicyViolations) == 0 {
+			continue
+		}
+		sha := commit.SHA
+		if len(sha) > 8 {
+			sha = sha[:8]
+		}
+		violating = append(violating, fmt.Sprintf("%s (%s)", sha, strings.Join(commit.PolicyViolations, "; ")))
+	}
+	if len(violating) == 0 {
+		return nil
+	}
+
+	if !cp.policyOverride {
+		return fmt.Errorf("%d commit(s) violate the policy for %s: %s",
+			len(violating), cp.config.Git.TargetBranch, strings.Join(violating, ", "))
+	}
+	fmt.Printf("⛔ Overriding the %s policy for: %s\n", cp.config.Git.TargetBranch, strings.Join(violating, ", "))
+	return nil
+}
+
+// policyOverrideNote returns the override recorded in the ledger for a picked commit, or ""
+func (cp *CherryPicker) policyOverrideNote(sha string) string {
+	if !cp.policyOverride {
+		return ""
+	}
+	for _, commit := range cp.commitsForSHAs([]string{sha}) {
+		if len(commit.PolicyViolations) > 0 {
+			return fmt.Sprintf("overridden by %s: %s", cp.authorName, strings.Join(commit.PolicyViolations, "; "))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// validateBranchPatterns checks that every glob in a branch pattern list is well formed
+func validateBranchPatterns(setting string, patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid %s pattern %q: %v", setting, pattern, err)
+		}
+	}
+	return nil
+}
+
+// isProtectedTarget reports whether the target branch matches an excluded (protected) branch pattern
+func (cp *CherryPicker) isProtectedTarget() bool {
+	return matchesBranchPattern(cp.config.Git.ExcludedBranches, cp.config.Git.TargetBranch)
+}
+
+// enterProtectConfirmMode asks the user to type the protected target's name before executing
+func (cp *CherryPicker) enterProtectConfirmMode() {
+	cp.protectConfirmMode = true
+	cp.protectConfirmInput = ""
+	cp.protectConfirmFailed = false
+}
+
+// exitProtectConfirmMode leaves the protected target confirmation prompt
+func (cp *CherryPicker) exitProtectConfirmMode() {
+	cp.protectConfirmMode = false
+	cp.protectConfirmInput = ""
+	cp.protectConfirmFailed = false
+}
+
+// requireProtectedConfirmation refuses a run onto a protected target that wasn't confirmed
+func (cp *CherryPicker) requireProtectedConfirmation() error {
+	if cp.isProtectedTarget() && !cp.protectedConfirmed {
+		return fmt.Errorf("%s is a protected branch; confirm it by name before cherry-picking onto it", cp.config.Git.TargetBranch)
+	}
+	return nil
+}
+
+// handleProtectConfirmInput handles typing the protected branch name to confirm execution
+func (cp *CherryPicker) handleProtectConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModeProtect, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "close":
+		cp.exitProtectConfirmMode()
+		return cp, nil
+	case "confirm":
+		if cp.protectConfirmInput != cp.config.Git.TargetBranch {
+			cp.protectConfirmFailed = true
+			cp.protectConfirmInput = ""
+			return cp, nil
+		}
+		cp.exitProtectConfirmMode()
+		cp.protectedConfirmed = true
+		cp.executeRequested = true
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "delete":
+		if len(cp.protectConfirmInput) > 0 {
+			cp.protectConfirmInput = cp.protectConfirmInput[:len(cp.protectConfirmInput)-1]
+		}
+		return cp, nil
+	case "help":
+		cp.openHelp()
+		return cp, nil
+	}
+
+	if len(msg.String()) == 1 && msg.String() >= " " && msg.String() <= "~" {
+		cp.protectConfirmInput += msg.String()
+	}
+	return cp, nil
+}
+
+// renderProtectConfirmView renders the protected target confirmation prompt
+func (cp *CherryPicker) renderProtectConfirmView() string {
+	var s strings.Builder
+	target := cp.config.Git.TargetBranch
+
+	s.WriteString("🔒 Protected Target Branch\n")
+	s.WriteString("═══════════════════════════════════════════════════════════════════════════════\n\n")
+	s.WriteString(fmt.Sprintf("%s matches excluded_branches and is protected.\n", target))
+	s.WriteString(fmt.Sprintf("You are about to cherry-pick %d commit(s) onto it.\n", len(cp.getSelectedSHAs())))
+	if cp.config.Behavior.AutoPush {
+		s.WriteString("Auto-push is blocked for protected branches; you will have to push manually.\n")
+	}
+	s.WriteString("\n")
+
+	s.WriteString(fmt.Sprintf("Type the branch name to confirm: %s█\n", cp.protectConfirmInput))
+	if cp.protectConfirmFailed {
+		s.WriteString(fmt.Sprintf("❌ That doesn't match %s.\n", target))
+	}
+	s.WriteString("\n")
+	s.WriteString("Controls: " + strings.Join(append([]string{"type=branch name"}, cp.controlItems(keyModeProtect)...), ", ") + "\n")
+
+	return s.String()
+}
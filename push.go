@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// remoteTargetRef returns the remote-tracking ref of the target branch, e.g. origin/release
+func (cp *CherryPicker) remoteTargetRef() string {
+	return cp.targetRemote() + "/" + cp.config.Git.TargetBranch
+}
+
+// recordRemoteHead remembers the remote target tip right after pulling, or "" if the
+// remote has no such branch
+func (cp *CherryPicker) recordRemoteHead() {
+	cp.runRemoteHead = ""
+	output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", cp.remoteTargetRef()).Output()
+	if err == nil {
+		cp.runRemoteHead = strings.TrimSpace(string(output))
+	}
+}
+
+// unpushedRange returns the rev-list arguments selecting local target commits missing on the remote
+func (cp *CherryPicker) unpushedRange(tip string) []string {
+	if cp.runRemoteHead != "" {
+		return []string{cp.runRemoteHead + ".." + tip}
+	}
+	return []string{tip, "--not", "--remotes=" + cp.targetRemote()}
+}
+
+// loadPushPreview lists the commits that a push of the target branch would publish, or
+// the commits a pull request run adds on its backport branch
+func (cp *CherryPicker) loadPushPreview() []string {
+	args := append([]string{"log", "--oneline"}, cp.unpushedRange(cp.config.Git.TargetBranch)...)
+	if cp.backportBranch != "" {
+		args = []string{"log", "--oneline", cp.config.Git.TargetBranch + ".." + cp.backportBranch}
+	}
+	output, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return []string{"Error loading commits: " + err.Error()}
+	}
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// checkPushSafety refuses a push when the local target was behind the remote when the run
+// started, when the remote moved since the pull or when the local target had commits of its
+// own before the run. It reports whether the run rewrote history the remote already has.
+func (cp *CherryPicker) checkPushSafety() (bool, error) {
+	remote := cp.targetRemote()
+	targetBranch := cp.config.Git.TargetBranch
+
+	// A target that wasn't pulled (auto_fetch off, or a failed pull) lacks the remote's
+	// newer commits, and publishing it would drop them
+	if cp.runRemoteHead != "" && cp.runStartHead != "" {
+		if exec.Command("git", "merge-base", "--is-ancestor", cp.runRemoteHead, cp.runStartHead).Run() != nil {
+			return false, fmt.Errorf("local %s is behind %s; pull it and run again", targetBranch, cp.remoteTargetRef())
+		}
+	}
+
+	// Local commits that predate the run would be published along with the picks
+	if cp.runStartHead != "" {
+		args := append([]string{"rev-list", "--count"}, cp.unpushedRange(cp.runStartHead)...)
+		output, err := exec.Command("git", args...).Output()
+		if err == nil && strings.TrimSpace(string(output)) != "0" {
+			return false, fmt.Errorf("local %s has %s commit(s) that weren't part of this run; push them separately first",
+				targetBranch, strings.TrimSpace(string(output)))
+		}
+	}
+
+	// Someone else may have pushed while we were picking
+	if err := exec.Command("git", "fetch", remote, targetBranch).Run(); err != nil {
+		if cp.runRemoteHead != "" {
+			fmt.Printf("⚠️  Could not fetch %s to check for new commits; relying on the push lease\n", cp.remoteTargetRef())
+		}
+	} else {
+		current := ""
+		if output, err := exec.Command("git", "rev-parse", "--verify", "--quiet", cp.remoteTargetRef()).Output(); err == nil {
+			current = strings.TrimSpace(string(output))
+		}
+		if current != cp.runRemoteHead {
+			return false, fmt.Errorf("%s moved since the pull; pull the new commits and push manually", cp.remoteTargetRef())
+		}
+	}
+
+	if cp.runRemoteHead == "" {
+		return false, nil
+	}
+	rewritten := exec.Command("git", "merge-base", "--is-ancestor", cp.runRemoteHead, targetBranch).Run() != nil
+	return rewritten, nil
+}
+
+// pushTarget pushes the target branch. The push is a plain one unless the run rewrote
+// history, which is only force-pushed, with a lease on the remote tip seen at the pull,
+// once the user confirmed it on the pre-push screen.
+func (cp *CherryPicker) pushTarget() error {
+	remote := cp.targetRemote()
+	targetBranch := cp.config.Git.TargetBranch
+
+	rewritten, err := cp.checkPushSafety()
+	if err != nil {
+		return fmt.Errorf("push refused: %v", err)
+	}
+
+	args := []string{"push", remote, targetBranch}
+	if rewritten {
+		if !cp.forceConfirmed {
+			cp.forcePush = true
+			cp.enterPushPreviewMode()
+			return fmt.Errorf("PUSH_PREVIEW")
+		}
+		// The lease makes the push fail if the remote changed after our check
+		lease := fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", targetBranch, cp.runRemoteHead)
+		fmt.Printf("⚠️  %s history was rewritten; pushing with %s\n", targetBranch, lease)
+		args = []string{"push", lease, remote, targetBranch}
+	}
+
+	fmt.Printf("🚀 Pushing to %s...\n", remote)
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to push: %v\n%s", err, strings.TrimSpace(string(output)))
+	}
+	fmt.Println("✅ Pushed successfully.")
+	return nil
+}
+
+// enterPushPreviewMode shows the commits about to be pushed and waits for confirmation
+func (cp *CherryPicker) enterPushPreviewMode() {
+	cp.pushPreviewMode = true
+	cp.pushPreview = cp.loadPushPreview()
+	cp.pushDecision = ""
+}
+
+// exitPushPreviewMode clears the pre-push preview state
+func (cp *CherryPicker) exitPushPreviewMode() {
+	cp.pushPreviewMode = false
+	cp.pushPreview = nil
+}
+
+// applyPushDecision acts on the choice made on the pre-push screen and finishes the run
+func (cp *CherryPicker) applyPushDecision() error {
+	decision := cp.pushDecision
+	cp.exitPushPreviewMode()
+	cp.pushDecision = ""
+
+	cp.pushConfirmed = true
+	if decision != "push" {
+		cp.skipPush = true
+	} else if cp.forcePush {
+		cp.forceConfirmed = true
+	}
+	return cp.publishRun()
+}
+
+// handlePushPreviewInput handles keyboard input on the pre-push screen
+func (cp *CherryPicker) handlePushPreviewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModePushPreview, msg) {
+	case "skip":
+		// Keep the picks local
+		cp.pushDecision = "skip"
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "push":
+		cp.pushDecision = "push"
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "help":
+		cp.openHelp()
+	}
+	return cp, nil
+}
+
+// renderPushPreviewView renders the pre-push screen
+func (cp *CherryPicker) renderPushPreviewView() string {
+	var s strings.Builder
+
+	s.WriteString("🚀 Ready to Push\n")
+	s.WriteString("═══════════════════════════════════════════════════════════════════════════════\n\n")
+	if cp.backportBranch != "" {
+		s.WriteString(fmt.Sprintf("🌿 %s → %s/%s\n", cp.backportBranch, cp.pushRemote(), cp.backportBranch))
+		s.WriteString(fmt.Sprintf("📣 A pull request into %s is opened after the push\n", cp.config.Git.TargetBranch))
+	} else {
+		s.WriteString(fmt.Sprintf("🌿 %s → %s\n", cp.config.Git.TargetBranch, cp.remoteTargetRef()))
+		if cp.runRemoteHead != "" {
+			s.WriteString(fmt.Sprintf("📍 Remote tip at pull: %s\n", cp.runRemoteHead[:8]))
+		} else {
+			s.WriteString("📍 Branch doesn't exist on the remote yet\n")
+		}
+	}
+	if cp.forcePush {
+		s.WriteString(fmt.Sprintf("⚠️  The run rewrote history %s already has; pushing forces it over the remote\n", cp.remoteTargetRef()))
+	}
+	s.WriteString("\n")
+
+	s.WriteString(fmt.Sprintf("Commits to push (%d):\n", len(cp.pushPreview)))
+	s.WriteString("───────────────────────────────────────────────────────────────────────────────\n")
+	if len(cp.pushPreview) == 0 {
+		s.WriteString("  (nothing to push)\n")
+	}
+	for _, line := range cp.pushPreview {
+		s.WriteString("  " + line + "\n")
+	}
+	s.WriteString("───────────────────────────────────────────────────────────────────────────────\n\n")
+
+	if cp.backportBranch == "" {
+		s.WriteString("Before pushing, the remote is fetched again; the push is refused if it moved,\n")
+		s.WriteString("if the branch was behind it or if it has commits that weren't part of this run.\n\n")
+	}
+	s.WriteString(cp.controlsLine(keyModePushPreview) + "\n")
+
+	return s.String()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newPushPicker returns a picker that picks onto release and pushes it directly
+func newPushPicker() *CherryPicker {
+	cp := newPullRequestPicker("")
+	cp.config.PullRequest.Enabled = false
+	cp.config.Hooks.PostRun = nil
+	return cp
+}
+
+func TestPushRefusesTargetBehindRemote(t *testing.T) {
+	work, origin, fix := newPullRequestRepo(t)
+
+	// Someone else pushes to release; the local release only fetches it
+	other := filepath.Join(filepath.Dir(work), "other")
+	git(t, filepath.Dir(work), "clone", "--quiet", "--branch", "release", origin, other)
+	os.WriteFile(filepath.Join(other, "other.txt"), []byte("other\n"), 0644)
+	git(t, other, "add", ".")
+	git(t, other, "commit", "--quiet", "-m", "Someone else's fix")
+	git(t, other, "push", "--quiet", "origin", "release")
+	remoteTip := git(t, other, "rev-parse", "HEAD")
+	git(t, work, "fetch", "--quiet", "origin")
+
+	cp := newPushPicker()
+	err := cp.cherryPickWithConflictHandling([]string{fix})
+	if err == nil || !strings.Contains(err.Error(), "behind") {
+		t.Fatalf("err = %v, want the push refused because release is behind", err)
+	}
+	if got := git(t, origin, "rev-parse", "release"); got != remoteTip {
+		t.Errorf("remote release = %s, want it left at %s", got, remoteTip)
+	}
+}
+
+func TestPushPublishesPicksWithoutForcing(t *testing.T) {
+	work, origin, fix := newPullRequestRepo(t)
+	releaseBefore := git(t, origin, "rev-parse", "release")
+
+	cp := newPushPicker()
+	if err := cp.cherryPickWithConflictHandling([]string{fix}); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if got, want := git(t, origin, "rev-parse", "release"), git(t, work, "rev-parse", "release"); got != want {
+		t.Errorf("remote release = %s, want the local tip %s", got, want)
+	}
+	if got := git(t, origin, "rev-list", "--count", releaseBefore+"..release"); got != "1" {
+		t.Errorf("remote release gained %s commit(s), want 1", got)
+	}
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CommitQuery is a parsed commit filter query. All terms must match.
+//
+// Terms are plain words, "exact phrases" and /regexes/ matched against the message, SHA,
+// author and changed files, or field filters. Plain words match the message fuzzily and
+// rank the results; phrases and regexes must match exactly.
+//
+//	author:alice  path:api/  after:2025-01-01  before:2025-02-01  type:fix
+//	ticket:ABC-1  applied:yes|no  size:<200  merge
+//
+// A leading "-" negates a term, e.g. -merge or -author:bot. Text values of author, path and
+// ticket may also be quoted or /regexes/.
+type CommitQuery struct {
+	terms []queryTerm
+}
+
+// queryTerm is a single condition of a CommitQuery
+type queryTerm struct {
+	negate bool
+	field  string         // "text", "author", "path", "ticket", "type", "after", "before", "applied", "size" or "merge"
+	text   string         // lower-cased literal for substring matches
+	fuzzy  bool           // plain word: fuzzy-match the message
+	re     *regexp.Regexp // regex for /.../ values
+	date   time.Time      // after/before
+	op     string         // size comparison: <, <=, >, >= or =
+	size   int            // size limit in changed lines
+	yes    bool           // applied:yes
+}
+
+// parseCommitQuery parses a filter query; an empty query matches every commit
+func parseCommitQuery(input string) (*CommitQuery, error) {
+	tokens, err := tokenizeQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &CommitQuery{}
+	for _, token := range tokens {
+		term, err := parseQueryTerm(token)
+		if err != nil {
+			return nil, err
+		}
+		query.terms = append(query.terms, term)
+	}
+	return query, nil
+}
+
+// tokenizeQuery splits a query on spaces, keeping "quoted phrases" and /regexes/ whole
+func tokenizeQuery(input string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuote, inRegex := false, false
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case inRegex:
+			current.WriteByte(c)
+			if c == '\\' && i+1 < len(input) {
+				i++
+				current.WriteByte(input[i])
+			} else if c == '/' {
+				inRegex = false
+			}
+		case inQuote:
+			current.WriteByte(c)
+			if c == '"' {
+				inQuote = false
+			}
+		case c == ' ' || c == '\t':
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			// Quotes and regexes open at the start of a value: after "", "-" or "key:"
+			prefix := current.String()
+			atValue := prefix == "" || prefix == "-" || strings.HasSuffix(prefix, ":")
+			if c == '"' && atValue {
+				inQuote = true
+			} else if c == '/' && atValue {
+				inRegex = true
+			}
+			current.WriteByte(c)
+		}
+	}
+
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inRegex {
+		return nil, fmt.Errorf("unterminated regex")
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// parseQueryTerm parses one token into a term
+func parseQueryTerm(token string) (queryTerm, error) {
+	term := queryTerm{field: "text"}
+	if strings.HasPrefix(token, "-") && len(token) > 1 {
+		term.negate = true
+		token = token[1:]
+	}
+
+	if token == "merge" {
+		term.field = "merge"
+		return term, nil
+	}
+
+	// Unknown keys such as "fix:" are searched as plain text
+	value := token
+	if key, rest, ok := strings.Cut(token, ":"); ok {
+		switch key {
+		case "author", "path", "ticket", "type", "after", "before", "applied", "size":
+			term.field = key
+			value = rest
+		}
+	}
+	if value == "" {
+		return term, fmt.Errorf("%s: needs a value", term.field)
+	}
+
+	switch term.field {
+	case "after", "before":
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return term, fmt.Errorf("%s: expects a YYYY-MM-DD date, got %q", term.field, value)
+		}
+		term.date = date
+	case "applied":
+		switch strings.ToLower(value) {
+		case "yes", "true", "y":
+			term.yes = true
+		case "no", "false", "n":
+			term.yes = false
+		default:
+			return term, fmt.Errorf("applied: expects yes or no, got %q", value)
+		}
+	case "size":
+		op := strings.TrimRight(value, "0123456789")
+		if op == "" {
+			op = "="
+		}
+		switch op {
+		case "<", "<=", ">", ">=", "=":
+		default:
+			return term, fmt.Errorf("size: expects <, <=, >, >= or = and a number, got %q", value)
+		}
+		size, err := strconv.Atoi(strings.TrimLeft(value, "<>="))
+		if err != nil {
+			return term, fmt.Errorf("size: expects a number of changed lines, got %q", value)
+		}
+		term.op, term.size = op, size
+	default:
+		if err := term.setText(value); err != nil {
+			return term, err
+		}
+	}
+	return term, nil
+}
+
+// setText sets a text value: a /regex/, a "quoted phrase" or a plain word
+func (t *queryTerm) setText(value string) error {
+	if len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		re, err := regexp.Compile(value[1 : len(value)-1])
+		if err != nil {
+			return fmt.Errorf("invalid regex %s: %v", value, err)
+		}
+		t.re = re
+		return nil
+	}
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		value = value[1 : len(value)-1]
+	} else {
+		t.fuzzy = t.field == "text"
+	}
+	t.text = foldCase(value)
+	return nil
+}
+
+// matchAny reports whether any of the strings matches the term's regex, or contains its
+// literal; lower holds the same strings lower-cased
+func (t queryTerm) matchAny(values, lower []string) bool {
+	for i := range values {
+		if t.re != nil {
+			if t.re.MatchString(values[i]) {
+				return true
+			}
+		} else if strings.Contains(lower[i], t.text) {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerAll lower-cases every string
+func lowerAll(values []string) []string {
+	lower := make([]string, len(values))
+	for i, value := range values {
+		lower[i] = foldCase(value)
+	}
+	return lower
+}
+
+// matchMessage matches the commit message, returning the fuzzy score and matched rune positions
+func (t queryTerm) matchMessage(commit Commit) (int, []int, bool) {
+	if t.re != nil {
+		if loc := t.re.FindStringIndex(commit.Message); loc != nil {
+			return 0, runePositions(commit.Message, loc[0], loc[1]), true
+		}
+		return 0, nil, false
+	}
+	// Positions are computed on the message's own runes, as they are highlighted there
+	if t.fuzzy {
+		return fuzzyMatch(t.text, commit.Message)
+	}
+	if i := foldedIndex(commit.Message, t.text); i >= 0 {
+		positions := make([]int, utf8.RuneCountInString(t.text))
+		for k := range positions {
+			positions[k] = i + k
+		}
+		return 0, positions, true
+	}
+	return 0, nil, false
+}
+
+// match reports whether a commit satisfies the term, with the fuzzy score and the rune
+// positions matched in the commit message
+func (t queryTerm) match(commit Commit) (bool, int, []int) {
+	if commit.lowerFull == "" {
+		commit.cacheSearchFields()
+	}
+
+	var ok bool
+	var score int
+	var positions []int
+	switch t.field {
+	case "author":
+		ok = t.matchAny([]string{commit.Author + " " + commit.AuthorEmail}, []string{commit.lowerAuthor})
+	case "path":
+		ok = t.matchAny(commit.FilesChanged, commit.lowerFiles)
+	case "ticket":
+		ok = t.matchAny(commit.Tickets, lowerAll(commit.Tickets))
+	case "type":
+		if t.re != nil {
+			ok = t.re.MatchString(commitType(commit))
+		} else {
+			ok = commitType(commit) == t.text
+		}
+	case "after":
+		ok = !commit.Date.Before(t.date)
+	case "before":
+		ok = commit.Date.Before(t.date)
+	case "applied":
+		ok = commit.AlreadyApplied == t.yes
+	case "size":
+		ok = compareSize(commit.Insertions+commit.Deletions, t.op, t.size)
+	case "merge":
+		ok = commit.IsMerge
+	default:
+		score, positions, ok = t.matchMessage(commit)
+		if !ok {
+			// SHA, author and file matches don't rank
+			values := append([]string{commit.Full, commit.Author + " " + commit.AuthorEmail}, commit.FilesChanged...)
+			lower := append([]string{commit.lowerFull, commit.lowerAuthor}, commit.lowerFiles...)
+			ok = t.matchAny(values, lower)
+		}
+	}
+
+	if t.negate {
+		return !ok, 0, nil
+	}
+	return ok, score, positions
+}
+
+// compareSize applies a size comparison operator
+func compareSize(size int, op string, limit int) bool {
+	switch op {
+	case "<":
+		return size < limit
+	case "<=":
+		return size <= limit
+	case ">":
+		return size > limit
+	case ">=":
+		return size >= limit
+	}
+	return size == limit
+}
+
+// String describes the term for the search bar
+func (t queryTerm) String() string {
+	var s string
+	value := fmt.Sprintf("%q", t.text)
+	if t.re != nil {
+		value = "/" + t.re.String() + "/"
+	}
+
+	switch t.field {
+	case "text":
+		if t.fuzzy {
+			s = "fuzzy " + value
+		} else {
+			s = "text ~ " + value
+		}
+	case "author", "path", "ticket":
+		s = t.field + " ~ " + value
+	case "type":
+		s = "type = " + value
+	case "after":
+		s = "date ≥ " + t.date.Format("2006-01-02")
+	case "before":
+		s = "date < " + t.date.Format("2006-01-02")
+	case "applied":
+		s = "applied"
+		if !t.yes {
+			s = "not applied"
+		}
+	case "size":
+		s = fmt.Sprintf("size %s %d", t.op, t.size)
+	case "merge":
+		s = "merge"
+	}
+
+	if t.negate {
+		return "NOT " + s
+	}
+	return s
+}
+
+// Matches reports whether a commit satisfies every term of the query
+func (q *CommitQuery) Matches(commit Commit) bool {
+	ok, _, _ := q.Match(commit)
+	return ok
+}
+
+// Match reports whether a commit satisfies every term, with the summed fuzzy score and the
+// rune positions to highlight in the commit message
+func (q *CommitQuery) Match(commit Commit) (bool, int, []int) {
+	total := 0
+	var highlights []int
+	for _, term := range q.terms {
+		ok, score, positions := term.match(commit)
+		if !ok {
+			return false, 0, nil
+		}
+		total += score
+		highlights = append(highlights, positions...)
+	}
+	return true, total, highlights
+}
+
+// Ranked reports whether results should be ordered by score: the query has a fuzzy word
+func (q *CommitQuery) Ranked() bool {
+	for _, term := range q.terms {
+		if term.fuzzy && !term.negate {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether the query has no terms
+func (q *CommitQuery) Empty() bool {
+	return len(q.terms) == 0
+}
+
+// String describes the parsed query, e.g. author ~ "alice" AND NOT merge
+func (q *CommitQuery) String() string {
+	var parts []string
+	for _, term := range q.terms {
+		parts = append(parts, term.String())
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// selectByQuery selects every not-yet-applied commit matching a query and returns how many
+func (cp *CherryPicker) selectByQuery(query *CommitQuery) int {
+	count := 0
+	for _, commit := range cp.commits {
+		if commit.AlreadyApplied || !query.Matches(commit) {
+			continue
+		}
+		cp.selected[commit.SHA] = true
+		count++
+	}
+	return count
+}
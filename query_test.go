@@ -0,0 +1,129 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCommitQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"login", `fuzzy "login"`},
+		{"-merge", "NOT merge"},
+		{"-author:bot", `NOT author ~ "bot"`},
+		{"size:<200", "size < 200"},
+		{"size:>=5", "size >= 5"},
+		{"size:10", "size = 10"},
+		{"path:api/", `path ~ "api/"`},
+		{`path:/\.go$/`, `path ~ /\.go$/`},
+		{`"Fix Login"`, `text ~ "fix login"`},
+		{`author:"Jane Doe"`, `author ~ "jane doe"`},
+		{`-"wip commit"`, `NOT text ~ "wip commit"`},
+		{"/^fix/", "text ~ /^fix/"},
+		{"/a b/ path:docs", `text ~ /a b/ AND path ~ "docs"`},
+		{"fix: login", `fuzzy "fix:" AND fuzzy "login"`},
+		{"after:2025-01-01 applied:no", "date ≥ 2025-01-01 AND not applied"},
+		{"type:fix ticket:ABC-1", `type = "fix" AND ticket ~ "abc-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := parseCommitQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseCommitQuery(%q): %v", tt.query, err)
+			}
+			if got := query.String(); got != tt.want {
+				t.Errorf("parsed = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommitQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`"unterminated`, "unterminated quote"},
+		{"/unterminated", "unterminated regex"},
+		{"author:", "author: needs a value"},
+		{"size:<>5", "size: expects <"},
+		{"size:<x", "size: expects <"},
+		{"size:<", "size: expects a number"},
+		{"after:yesterday", "after: expects a YYYY-MM-DD date"},
+		{"applied:maybe", "applied: expects yes or no"},
+		{"/[/", "invalid regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := parseCommitQuery(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// queryCommits returns commits a..d with authors, files and sizes to filter on
+func queryCommits() []Commit {
+	return []Commit{
+		{SHA: "a", Message: "Fix login redirect", Author: "Alice", FilesChanged: []string{"api/login.go"}, Insertions: 10},
+		{SHA: "b", Message: "Merge branch 'dev'", Author: "Bot", IsMerge: true, Insertions: 500},
+		{SHA: "c", Message: "Update docs", Author: "Jane Doe", FilesChanged: []string{"docs/index.md"}, Insertions: 150, Deletions: 100},
+		{SHA: "d", Message: "WIP commit", Author: "Alice", FilesChanged: []string{"api/wip.go"}, AlreadyApplied: true},
+	}
+}
+
+func TestCommitQueryMatches(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "abcd"},
+		{"-merge", "acd"},
+		{"-author:alice", "bc"},
+		{"size:<200", "ad"},
+		{"size:>=250", "bc"},
+		{"path:api/", "ad"},
+		{"-path:api/", "bc"},
+		{`path:/\.md$/`, "c"},
+		{`author:"jane doe"`, "c"},
+		{`"login redirect"`, "a"},
+		{`-"wip commit"`, "abc"},
+		{"applied:no path:api", "a"},
+		{"lgn", "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := parseCommitQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseCommitQuery(%q): %v", tt.query, err)
+			}
+			var matched []Commit
+			for _, commit := range queryCommits() {
+				if query.Matches(commit) {
+					matched = append(matched, commit)
+				}
+			}
+			if got := shas(matched); got != tt.want {
+				t.Errorf("matched = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectByQuerySkipsApplied(t *testing.T) {
+	cp := &CherryPicker{commits: queryCommits(), selected: map[string]bool{}}
+	query, err := parseCommitQuery("author:alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := cp.selectByQuery(query); count != 1 || !cp.selected["a"] || cp.selected["d"] {
+		t.Errorf("selected %d commits %v, want only a", count, cp.selected)
+	}
+}
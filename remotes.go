@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// listRemotes returns the names of the configured git remotes
+func listRemotes() ([]string, error) {
+	output, err := exec.Command("git", "remote").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %v", err)
+	}
+
+	var remotes []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			remotes = append(remotes, name)
+		}
+	}
+	return remotes, nil
+}
+
+// hasRemote reports whether a remote with exactly this name is configured
+func hasRemote(name string) bool {
+	if name == "" {
+		return false
+	}
+	remotes, err := listRemotes()
+	if err != nil {
+		return false
+	}
+	for _, remote := range remotes {
+		if remote == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceRemote is the remote commits are read from (default: git.remote)
+func (g GitConfig) sourceRemote() string {
+	if g.SourceRemote != "" {
+		return g.SourceRemote
+	}
+	return g.Remote
+}
+
+// targetRemote is the remote holding the target branch, pulled before and pushed after a run
+// (default: git.remote)
+func (g GitConfig) targetRemote() string {
+	if g.TargetRemote != "" {
+		return g.TargetRemote
+	}
+	return g.Remote
+}
+
+// pushRemote is the remote backport branches for pull requests are pushed to, e.g. a fork
+// (default: the target remote)
+func (g GitConfig) pushRemote() string {
+	if g.PushRemote != "" {
+		return g.PushRemote
+	}
+	return g.targetRemote()
+}
+
+// sourceRemote returns the configured source remote
+func (cp *CherryPicker) sourceRemote() string {
+	return cp.config.Git.sourceRemote()
+}
+
+// targetRemote returns the configured target remote
+func (cp *CherryPicker) targetRemote() string {
+	return cp.config.Git.targetRemote()
+}
+
+// pushRemote returns the configured push remote
+func (cp *CherryPicker) pushRemote() string {
+	return cp.config.Git.pushRemote()
+}
+
+// remoteBranchRef returns remote/branch if the remote exists, or "" otherwise
+func remoteBranchRef(remote, branch string) string {
+	if !hasRemote(remote) {
+		return ""
+	}
+	return remote + "/" + branch
+}
+
+// resolveBranchRef returns the remote-tracking branch if it exists, else the local branch,
+// or "" if neither exists
+func resolveBranchRef(remote, branch string) string {
+	if ref := remoteBranchRef(remote, branch); ref != "" {
+		if err := exec.Command("git", "rev-parse", "--verify", "--quiet", ref).Run(); err == nil {
+			return ref
+		}
+	}
+	if err := exec.Command("git", "rev-parse", "--verify", "--quiet", branch).Run(); err == nil {
+		return branch
+	}
+	return ""
+}
+
+// fetchRemotes fetches the source and target remotes, skipping ones that aren't configured
+func (cp *CherryPicker) fetchRemotes() {
+	fetched := make(map[string]bool)
+	for _, remote := range []string{cp.sourceRemote(), cp.targetRemote()} {
+		if fetched[remote] {
+			continue
+		}
+		fetched[remote] = true
+
+		if !hasRemote(remote) {
+			fmt.Printf("⚠️  No '%s' remote configured, working with local branches only\n", remote)
+			continue
+		}
+		if err := exec.Command("git", "fetch", remote).Run(); err != nil {
+			fmt.Printf("⚠️  Could not fetch from %s, working with local branches only\n", remote)
+		}
+	}
+}
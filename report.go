@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ReportCommit is a picked commit as shown in a release report
+type ReportCommit struct {
+	Commit
+	TargetSHA  string
+	Stats      string // Output of getCommitStats
+	Conflict   bool
+	Resolution string
+}
+
+// ReportData is the data passed to report templates
+type ReportData struct {
+	Session     string
+	User        string
+	Source      string
+	Target      string
+	Time        time.Time
+	Pushed      bool
+	PullRequest string
+	Commits     []ReportCommit
+	Conflicts   []ReportCommit
+	Authors     []string
+	Tickets     []string
+	Insertions  int
+	Deletions   int
+}
+
+// reportExtensions maps report formats to file extensions and template names
+var reportExtensions = map[string]string{
+	"markdown": "md",
+	"html":     "html",
+	"csv":      "csv",
+}
+
+var defaultReportTemplates = map[string]string{
+	"markdown": `# Backport report: {{.Source}} → {{.Target}}
+
+- **Run:** {{.Session}} by {{.User}} on {{.Time.Format "2006-01-02 15:04"}}
+- **Pushed:** {{if .Pushed}}yes{{else}}no{{end}}
+{{- if .PullRequest}}
+- **Pull request:** {{.PullRequest}}
+{{- end}}
+- **Commits:** {{len .Commits}} (+{{.Insertions}} -{{.Deletions}})
+{{- if .Authors}}
+- **Authors:** {{join .Authors ", "}}
+{{- end}}
+{{- if .Tickets}}
+- **Tickets:** {{join .Tickets ", "}}
+{{- end}}
+
+## Commits
+{{range .Commits}}
+### {{short .SHA}} {{.Message}}
+
+{{if .Author}}Author: {{.Author}}{{end}}{{if .Tickets}} · Tickets: {{join .Tickets ", "}}{{end}}{{if .TargetSHA}} · Picked as {{short .TargetSHA}}{{end}}
+
+{{if .Stats}}` + "```" + `
+{{.Stats}}` + "```" + `
+{{end}}{{end}}
+{{- if .Conflicts}}
+## Conflicts
+{{range .Conflicts}}
+- {{short .SHA}} {{.Message}}: {{.Resolution}}
+{{- end}}
+{{end}}`,
+
+	"html": `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Backport report: {{.Source}} → {{.Target}}</title></head>
+<body>
+<h1>Backport report: {{.Source}} → {{.Target}}</h1>
+<ul>
+  <li><strong>Run:</strong> {{.Session}} by {{.User}} on {{.Time.Format "2006-01-02 15:04"}}</li>
+  <li><strong>Pushed:</strong> {{if .Pushed}}yes{{else}}no{{end}}</li>
+  {{if .PullRequest}}<li><strong>Pull request:</strong> <a href="{{.PullRequest}}">{{.PullRequest}}</a></li>{{end}}
+  <li><strong>Commits:</strong> {{len .Commits}} (+{{.Insertions}} -{{.Deletions}})</li>
+  {{if .Authors}}<li><strong>Authors:</strong> {{join .Authors ", "}}</li>{{end}}
+  {{if .Tickets}}<li><strong>Tickets:</strong> {{join .Tickets ", "}}</li>{{end}}
+</ul>
+<h2>Commits</h2>
+<table>
+  <tr><th>SHA</th><th>Message</th><th>Author</th><th>Tickets</th><th>Changes</th><th>Picked as</th></tr>
+  {{range .Commits}}<tr><td>{{short .SHA}}</td><td>{{.Message}}</td><td>{{.Author}}</td><td>{{join .Tickets ", "}}</td><td>+{{.Insertions}} -{{.Deletions}}</td><td>{{short .TargetSHA}}</td></tr>
+  {{end}}
+</table>
+{{if .Conflicts}}<h2>Conflicts</h2>
+<ul>
+  {{range .Conflicts}}<li>{{short .SHA}} {{.Message}}: {{.Resolution}}</li>
+  {{end}}
+</ul>{{end}}
+</body>
+</html>
+`,
+
+	"csv": `{{csv "sha" "target_sha" "message" "author" "tickets" "insertions" "deletions" "conflict" "resolution"}}
+{{range .Commits}}{{csv .SHA .TargetSHA .Message .Author (join .Tickets " ") .Insertions .Deletions .Conflict .Resolution}}
+{{end}}`,
+}
+
+// reportFuncs are the helpers available to report templates
+var reportFuncs = template.FuncMap{
+	"join": strings.Join,
+	"short": func(sha string) string {
+		if len(sha) > 8 {
+			return sha[:8]
+		}
+		return sha
+	},
+	"csv": func(fields ...interface{}) (string, error) {
+		var record []string
+		for _, field := range fields {
+			record = append(record, fmt.Sprint(field))
+		}
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+		w.Flush()
+		return strings.TrimRight(buf.String(), "\n"), w.Error()
+	},
+}
+
+// buildReport gathers report data for a ledger run
+func (cp *CherryPicker) buildReport(run LedgerRun) ReportData {
+	data := ReportData{
+		Session:     run.Session,
+		User:        run.User,
+		Source:      run.Source,
+		Target:      run.Target,
+		Time:        run.Timestamp,
+		Pushed:      run.Pushed,
+		PullRequest: run.PullRequest,
+	}
+
+	ticketPattern, _ := compileTicketPattern(cp.config.Tickets.Pattern)
+
+	// Collapse the entries of each source commit, keeping the latest outcome
+	var order []string
+	latest := make(map[string]LedgerEntry)
+	conflicted := make(map[string]bool)
+	for _, entry := range run.Entries {
+		if _, ok := latest[entry.SourceSHA]; !ok {
+			order = append(order, entry.SourceSHA)
+		}
+		latest[entry.SourceSHA] = entry
+		if entry.Conflict {
+			conflicted[entry.SourceSHA] = true
+		}
+	}
+
+	authorSet := make(map[string]bool)
+	ticketSet := make(map[string]bool)
+	for _, sha := range order {
+		entry := latest[sha]
+		if entry.Message == "" {
+			entry.Message = getCommitSubject(sha)
+		}
+
+		commit, err := cp.getCommitDetails(sha, entry.Message, sha+" "+entry.Message)
+		if err != nil {
+			commit = Commit{SHA: sha, Message: entry.Message}
+		}
+		commit.Tickets = commitTickets(ticketPattern, commit)
+
+		reportCommit := ReportCommit{
+			Commit:     commit,
+			TargetSHA:  entry.TargetSHA,
+			Conflict:   conflicted[sha],
+			Resolution: entry.Resolution,
+		}
+		if stats, err := cp.getCommitStats(sha); err == nil {
+			reportCommit.Stats = stats
+		}
+
+		if conflicted[sha] {
+			data.Conflicts = append(data.Conflicts, reportCommit)
+		}
+		if entry.TargetSHA == "" {
+			// Skipped or reverted picks only show up under conflicts
+			continue
+		}
+
+		data.Commits = append(data.Commits, reportCommit)
+		data.Insertions += commit.Insertions
+		data.Deletions += commit.Deletions
+		if commit.Author != "" && !authorSet[commit.Author] {
+			authorSet[commit.Author] = true
+			data.Authors = append(data.Authors, commit.Author)
+		}
+		for _, ticket := range commit.Tickets {
+			if !ticketSet[ticket] {
+				ticketSet[ticket] = true
+				data.Tickets = append(data.Tickets, ticket)
+			}
+		}
+	}
+
+	sort.Strings(data.Authors)
+	sort.Strings(data.Tickets)
+	return data
+}
+
+// renderReport renders report data with the template for a format, preferring
+// <templateDir>/report.<ext>.tmpl over the built-in template
+func renderReport(data ReportData, format, templateDir string) (string, error) {
+	ext, ok := reportExtensions[format]
+	if !ok {
+		return "", fmt.Errorf("unknown report format: %s (use markdown, html or csv)", format)
+	}
+
+	text := defaultReportTemplates[format]
+	if templateDir != "" {
+		path := filepath.Join(templateDir, "report."+ext+".tmpl")
+		if custom, err := os.ReadFile(path); err == nil {
+			text = string(custom)
+		} else if !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read report template: %v", err)
+		}
+	}
+
+	// html/template escapes whatever the template prints, custom templates included
+	var tmpl interface {
+		Execute(io.Writer, any) error
+	}
+	var err error
+	if format == "html" {
+		tmpl, err = htmltemplate.New("report").Funcs(htmltemplate.FuncMap(reportFuncs)).Parse(text)
+	} else {
+		tmpl, err = template.New("report").Funcs(reportFuncs).Parse(text)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid report template: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render report: %v", err)
+	}
+	return out.String(), nil
+}
+
+// currentRun returns the ledger run for this session, or one built from the outcomes
+// recorded during the run when the ledger is disabled
+func (cp *CherryPicker) currentRun() LedgerRun {
+	if cp.config.Ledger.Enabled {
+		if ledger, err := LoadLedger(cp.config); err == nil {
+			for _, run := range ledger.Runs {
+				if run.Session == cp.session() {
+					return run
+				}
+			}
+		}
+	}
+
+	run := LedgerRun{
+		Session:     cp.session(),
+		User:        cp.authorName,
+		Timestamp:   time.Now(),
+		Source:      cp.config.Git.SourceBranch,
+		Target:      cp.config.Git.TargetBranch,
+		Pushed:      cp.runPushed,
+		PullRequest: cp.pullRequestURL,
+		Entries:     cp.runEntries,
+	}
+	return run
+}
+
+// writeRunReport writes the report for the current run after it finishes
+func (cp *CherryPicker) writeRunReport() {
+	reportConfig := cp.config.Report
+	format := reportConfig.Format
+	if format == "" {
+		format = "markdown"
+	}
+
+	content, err := renderReport(cp.buildReport(cp.currentRun()), format, reportConfig.TemplateDir)
+	if err != nil {
+		fmt.Printf("⚠️  Could not generate report: %v\n", err)
+		return
+	}
+
+	path := reportConfig.Output
+	if path == "" {
+		// Keep reports out of the working tree
+		gitDir, err := gitCommonDir()
+		if err != nil {
+			fmt.Printf("⚠️  Could not write report: %v\n", err)
+			return
+		}
+		path = filepath.Join(gitDir, "cherry-picker", "reports", fmt.Sprintf("report-%s.%s", cp.session(), reportExtensions[format]))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			fmt.Printf("⚠️  Could not write report: %v\n", err)
+			return
+		}
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		fmt.Printf("⚠️  Could not write report: %v\n", err)
+		return
+	}
+	fmt.Printf("📄 Report written to %s\n", path)
+}
+
+// runReport renders a report for a recorded run for the `report` command
+func runReport(config *Config, session, format, output string) error {
+	ledger, err := LoadLedger(config)
+	if err != nil {
+		return err
+	}
+	run, err := ledger.findRun(session)
+	if err != nil {
+		return err
+	}
+
+	cp := &CherryPicker{config: config, selected: make(map[string]bool)}
+	content, err := renderReport(cp.buildReport(run), format, config.Report.TemplateDir)
+	if err != nil {
+		return err
+	}
+
+	if output == "" || output == "-" {
+		fmt.Print(content)
+		return nil
+	}
+	if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %v", err)
+	}
+	fmt.Printf("📄 Report written to %s\n", output)
+	return nil
+}
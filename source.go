@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// resolveSourceRev resolves one side of the source to something git log accepts: the remote-tracking
+// branch or local branch of that name, or any other rev such as a tag or SHA. Refs like
+// refs/pull/42/head that aren't present locally are fetched from the source remote.
+func (cp *CherryPicker) resolveSourceRev(rev string) (string, error) {
+	if ref := resolveBranchRef(cp.sourceRemote(), rev); ref != "" {
+		return ref, nil
+	}
+	if revExists(rev) {
+		return rev, nil
+	}
+
+	remote := cp.sourceRemote()
+	if strings.HasPrefix(rev, "refs/") && hasRemote(remote) {
+		fmt.Printf("📥 Fetching %s from %s...\n", rev, remote)
+		if err := exec.Command("git", "fetch", remote, "+"+rev+":"+rev).Run(); err == nil && revExists(rev) {
+			return rev, nil
+		}
+	}
+	return "", fmt.Errorf("source '%s' not found", rev)
+}
+
+// revExists reports whether rev names a commit
+func revExists(rev string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").Run() == nil
+}
+
+// sourceRevisions returns the git log arguments selecting the source commits that aren't reachable
+// from the target. The source may be a branch, any rev, or a range like v1.2..v1.3 or a...b,
+// and is narrowed by the since/until settings.
+func (cp *CherryPicker) sourceRevisions() ([]string, error) {
+	source := strings.TrimSpace(cp.config.Git.SourceBranch)
+	if source == "" {
+		return nil, fmt.Errorf("no source configured")
+	}
+
+	var args []string
+	separator := ""
+	if strings.Contains(source, "...") {
+		separator = "..."
+	} else if strings.Contains(source, "..") {
+		separator = ".."
+	}
+
+	if separator == "" {
+		rev, err := cp.resolveSourceRev(source)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, rev)
+	} else {
+		// An empty side of a range means HEAD, as in git
+		from, to, _ := strings.Cut(source, separator)
+		resolved := make([]string, 2)
+		for i, side := range []string{from, to} {
+			if side == "" {
+				side = "HEAD"
+			}
+			rev, err := cp.resolveSourceRev(side)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = rev
+		}
+		args = append(args, resolved[0]+separator+resolved[1])
+	}
+
+	// Only load commits the target doesn't have yet
+	if targetRef := cp.resolveTargetRef(); targetRef != "" {
+		args = append(args, "^"+targetRef)
+	}
+
+	if cp.config.Git.Since != "" {
+		args = append(args, "--since="+cp.config.Git.Since)
+	}
+	if cp.config.Git.Until != "" {
+		args = append(args, "--until="+cp.config.Git.Until)
+	}
+	return args, nil
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// syntaxLang describes just enough of a language to color a single line: keywords, comments
+// and string quotes. Block comments are only recognized within a line.
+type syntaxLang struct {
+	keywords     map[string]bool
+	lineComments []string
+	blockComment [2]string
+	quotes       string
+}
+
+// Token colors (256-color palette)
+const (
+	syntaxNone    = -1
+	syntaxKeyword = 141
+	syntaxString  = 186
+	syntaxComment = 244
+	syntaxNumber  = 173
+)
+
+// keywordSet builds a keyword lookup from a space-separated list
+func keywordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+var (
+	cFamilyKeywords = "if else for while do switch case default break continue return goto struct union enum typedef " +
+		"const static extern void int char long short float double unsigned signed sizeof true false null nullptr " +
+		"class public private protected virtual override new delete this namespace using template typename try catch throw " +
+		"final abstract interface extends implements package import boolean byte var val fun object when in is as"
+
+	syntaxLanguages = map[string]*syntaxLang{
+		"go": {
+			keywords: keywordSet("break case chan const continue default defer else fallthrough for func go goto if import " +
+				"interface map package range return select struct switch type var nil true false iota"),
+			lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: "\"'`",
+		},
+		"js": {
+			keywords: keywordSet("async await break case catch class const continue debugger default delete do else export " +
+				"extends finally for from function if import in instanceof let new of return static super switch this throw " +
+				"try typeof var void while yield null undefined true false interface type enum implements readonly as"),
+			lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: "\"'`",
+		},
+		"python": {
+			keywords: keywordSet("and as assert async await break class continue def del elif else except finally for from " +
+				"global if import in is lambda nonlocal not or pass raise return try while with yield None True False self"),
+			lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"ruby": {
+			keywords: keywordSet("alias and begin break case class def defined do else elsif end ensure false for if in module " +
+				"next nil not or redo rescue retry return self super then true undef unless until when while yield require"),
+			lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"rust": {
+			keywords: keywordSet("as async await break const continue crate dyn else enum extern false fn for if impl in let " +
+				"loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while"),
+			lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: "\"",
+		},
+		"c": {
+			keywords:     keywordSet(cFamilyKeywords),
+			lineComments: []string{"//"}, blockComment: [2]string{"/*", "*/"}, quotes: "\"'",
+		},
+		"shell": {
+			keywords: keywordSet("if then else elif fi for while until do done case esac in function return local export " +
+				"readonly set unset shift exit echo"),
+			lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"yaml": {
+			keywords:     keywordSet("true false null yes no on off"),
+			lineComments: []string{"#"}, quotes: "\"'",
+		},
+		"json": {
+			keywords: keywordSet("true false null"),
+			quotes:   "\"",
+		},
+		"sql": {
+			keywords: keywordSet("select from where insert into values update set delete create table alter drop index join " +
+				"left right inner outer on group by order having limit and or not null as distinct primary key references " +
+				"SELECT FROM WHERE INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE ALTER DROP INDEX JOIN LEFT RIGHT INNER " +
+				"OUTER ON GROUP BY ORDER HAVING LIMIT AND OR NOT NULL AS DISTINCT PRIMARY KEY REFERENCES"),
+			lineComments: []string{"--"}, blockComment: [2]string{"/*", "*/"}, quotes: "'\"",
+		},
+		"css": {
+			keywords:     keywordSet("important media import from to"),
+			blockComment: [2]string{"/*", "*/"}, quotes: "\"'",
+		},
+	}
+
+	syntaxExtensions = map[string]string{
+		".go": "go", ".js": "js", ".jsx": "js", ".mjs": "js", ".cjs": "js", ".ts": "js", ".tsx": "js",
+		".py": "python", ".rb": "ruby", ".rs": "rust",
+		".c": "c", ".h": "c", ".cc": "c", ".cpp": "c", ".hpp": "c", ".cs": "c", ".java": "c", ".kt": "c",
+		".scala": "c", ".swift": "c", ".php": "c",
+		".sh": "shell", ".bash": "shell", ".zsh": "shell",
+		".yml": "yaml", ".yaml": "yaml", ".toml": "yaml", ".json": "json",
+		".sql": "sql", ".css": "css", ".scss": "css",
+	}
+)
+
+// languageForFile picks the syntax of a file by its extension or name, or nil if unknown
+func languageForFile(file string) *syntaxLang {
+	base := path.Base(file)
+	switch base {
+	case "Makefile", "Dockerfile", ".bashrc", ".zshrc":
+		return syntaxLanguages["shell"]
+	}
+	return syntaxLanguages[syntaxExtensions[strings.ToLower(path.Ext(base))]]
+}
+
+// highlightSyntax returns a color per rune of a line (syntaxNone for plain text)
+func highlightSyntax(lang *syntaxLang, line []rune) []int {
+	colors := make([]int, len(line))
+	for i := range colors {
+		colors[i] = syntaxNone
+	}
+	if lang == nil {
+		return colors
+	}
+
+	paint := func(from, to, color int) {
+		for i := from; i < to && i < len(line); i++ {
+			colors[i] = color
+		}
+	}
+	for i := 0; i < len(line); {
+		r := line[i]
+		switch {
+		case anyPrefix(line, i, lang.lineComments):
+			paint(i, len(line), syntaxComment)
+			return colors
+		case lang.blockComment[0] != "" && runesHavePrefix(line, i, lang.blockComment[0]):
+			// Comment to the closing marker, or the end of the line
+			body := i + utf8.RuneCountInString(lang.blockComment[0])
+			stop := len(line)
+			if end := strings.Index(string(line[body:]), lang.blockComment[1]); end >= 0 {
+				stop = body + utf8.RuneCountInString(string(line[body:])[:end]) + utf8.RuneCountInString(lang.blockComment[1])
+			}
+			paint(i, stop, syntaxComment)
+			i = stop
+		case strings.ContainsRune(lang.quotes, r):
+			j := i + 1
+			for j < len(line) && line[j] != r {
+				if line[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			paint(i, j+1, syntaxString)
+			i = j + 1
+		case unicode.IsDigit(r) && (i == 0 || !isWordRune(line[i-1])):
+			j := i
+			for j < len(line) && (isWordRune(line[j]) || line[j] == '.') {
+				j++
+			}
+			paint(i, j, syntaxNumber)
+			i = j
+		case isWordRune(r):
+			j := i
+			for j < len(line) && isWordRune(line[j]) {
+				j++
+			}
+			if lang.keywords[string(line[i:j])] {
+				paint(i, j, syntaxKeyword)
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	return colors
+}
+
+// anyPrefix reports whether the runes from i start with one of the prefixes
+func anyPrefix(line []rune, i int, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if runesHavePrefix(line, i, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// runesHavePrefix reports whether the runes from i start with prefix
+func runesHavePrefix(line []rune, i int, prefix string) bool {
+	for _, r := range prefix {
+		if i >= len(line) || line[i] != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// isWordRune reports whether r can be part of an identifier
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordTokens splits a line into words and single other characters, returning each token's
+// rune offset
+func wordTokens(line []rune) (tokens []string, offsets []int) {
+	for i := 0; i < len(line); {
+		j := i + 1
+		if isWordRune(line[i]) {
+			for j < len(line) && isWordRune(line[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(line[i:j]))
+		offsets = append(offsets, i)
+		i = j
+	}
+	return tokens, offsets
+}
+
+// maxWordDiffTokens bounds the word diff so very long lines stay cheap
+const maxWordDiffTokens = 300
+
+// wordDiff marks the runes of a removed and an added line that aren't part of their longest
+// common word sequence, for intra-line highlights
+func wordDiff(removed, added []rune) (removedMarks, addedMarks []bool) {
+	removedMarks = make([]bool, len(removed))
+	addedMarks = make([]bool, len(added))
+	a, aOffsets := wordTokens(removed)
+	b, bOffsets := wordTokens(added)
+	if len(a) > maxWordDiffTokens || len(b) > maxWordDiffTokens {
+		return removedMarks, addedMarks
+	}
+
+	// Longest common subsequence of tokens
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Lines with little in common are highlighted as a whole by their line color alone
+	if common := lcs[0][0]; common == 0 || common*3 < min(len(a), len(b)) {
+		return removedMarks, addedMarks
+	}
+
+	mark := func(marks []bool, offset int, token string) {
+		for k := 0; k < len([]rune(token)); k++ {
+			marks[offset+k] = true
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			mark(addedMarks, bOffsets[j], b[j])
+			j++
+		default:
+			mark(removedMarks, aOffsets[i], a[i])
+			i++
+		}
+	}
+	return removedMarks, addedMarks
+}
+
+// styleRunes renders runes with a background color, a stronger background on marked runes,
+// and per-rune foreground colors. A background of syntaxNone keeps the terminal's.
+func styleRunes(line []rune, colors []int, marks []bool, background, markBackground int) string {
+	var b strings.Builder
+	current := ""
+	for i, r := range line {
+		bg := background
+		if marks != nil && marks[i] {
+			bg = markBackground
+		}
+		style := "\033[0m"
+		if bg != syntaxNone {
+			style += fmt.Sprintf("\033[48;5;%dm", bg)
+		}
+		if colors != nil && colors[i] != syntaxNone {
+			style += fmt.Sprintf("\033[38;5;%dm", colors[i])
+		}
+		if style != current {
+			b.WriteString(style)
+			current = style
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString("\033[0m")
+	return b.String()
+}
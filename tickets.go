@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// noTicketGroup is the group name for commits without a ticket reference
+const noTicketGroup = "(no ticket)"
+
+// compileTicketPattern compiles the configured ticket pattern, or returns nil when none is
+// configured. There is no default: any generic key pattern also matches UTF-8 or SHA-256.
+func compileTicketPattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket pattern %q: %v", pattern, err)
+	}
+	return re, nil
+}
+
+// extractTickets returns the unique ticket keys found in text, in order of appearance
+func extractTickets(re *regexp.Regexp, text string) []string {
+	if re == nil {
+		return nil
+	}
+
+	var tickets []string
+	seen := make(map[string]bool)
+	for _, match := range re.FindAllString(text, -1) {
+		if !seen[match] {
+			seen[match] = true
+			tickets = append(tickets, match)
+		}
+	}
+	return tickets
+}
+
+// commitTickets returns the ticket keys referenced in a commit's subject and body
+func commitTickets(re *regexp.Regexp, commit Commit) []string {
+	return extractTickets(re, commit.Message+"\n"+commit.Body)
+}
+
+// ticketGroup returns the group a commit belongs to when grouping by ticket
+func ticketGroup(commit Commit) string {
+	if len(commit.Tickets) == 0 {
+		return noTicketGroup
+	}
+	return commit.Tickets[0]
+}
+
+// groupCommitsByTicket orders commits by ticket group, keeping the first-seen order of groups.
+// Collapsed groups are reduced to their first commit.
+func (cp *CherryPicker) groupCommitsByTicket(commits []Commit) []Commit {
+	var order []string
+	groups := make(map[string][]Commit)
+	for _, commit := range commits {
+		group := ticketGroup(commit)
+		if _, ok := groups[group]; !ok && group != noTicketGroup {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], commit)
+	}
+	if _, ok := groups[noTicketGroup]; ok {
+		order = append(order, noTicketGroup)
+	}
+
+	var grouped []Commit
+	for _, group := range order {
+		if cp.collapsedTickets[group] {
+			grouped = append(grouped, groups[group][0])
+			continue
+		}
+		grouped = append(grouped, groups[group]...)
+	}
+	return grouped
+}
+
+// getTicketCommits returns all loaded commits in the given ticket group
+func (cp *CherryPicker) getTicketCommits(group string) []Commit {
+	var commits []Commit
+	for _, commit := range cp.commits {
+		if ticketGroup(commit) == group {
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// toggleGroupByTicket turns ticket grouping on or off, keeping the cursor on the current commit
+func (cp *CherryPicker) toggleGroupByTicket() {
+	cp.keepCursor(func() { cp.groupByTicket = !cp.groupByTicket })
+}
+
+// toggleTicketCollapse collapses or expands the group of the current commit
+func (cp *CherryPicker) toggleTicketCollapse() {
+	commit := cp.getCurrentCommit()
+	if commit == nil || !cp.groupByTicket {
+		return
+	}
+
+	group := ticketGroup(*commit)
+	if cp.collapsedTickets == nil {
+		cp.collapsedTickets = make(map[string]bool)
+	}
+	cp.collapsedTickets[group] = !cp.collapsedTickets[group]
+
+	// Keep the cursor on the group's first row
+	for i, visible := range cp.getVisibleCommits() {
+		if ticketGroup(visible) == group {
+			cp.currentIndex = i
+			break
+		}
+	}
+}
+
+// selectTicket selects every commit sharing the current commit's ticket,
+// or clears them if they are all already selected
+func (cp *CherryPicker) selectTicket() {
+	commit := cp.getCurrentCommit()
+	if commit == nil || len(commit.Tickets) == 0 {
+		return
+	}
+
+	commits := cp.getTicketCommits(ticketGroup(*commit))
+	allSelected := true
+	for _, c := range commits {
+		if !c.AlreadyApplied && !cp.selected[c.SHA] {
+			allSelected = false
+			break
+		}
+	}
+
+	for _, c := range commits {
+		if c.AlreadyApplied {
+			continue
+		}
+		cp.selected[c.SHA] = !allSelected
+	}
+}
+
+// renderTicketHeader returns the group header line shown above a ticket group
+func (cp *CherryPicker) renderTicketHeader(group string) string {
+	commits := cp.getTicketCommits(group)
+	selectedCount := 0
+	for _, commit := range commits {
+		if cp.selected[commit.SHA] {
+			selectedCount++
+		}
+	}
+
+	marker := "▾"
+	if cp.collapsedTickets[group] {
+		marker = "▸"
+	}
+	return fmt.Sprintf("%s 🎫 %s (%d commits, %d selected)\n", marker, group, len(commits), selectedCount)
+}
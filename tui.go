@@ -22,12 +22,40 @@ func (cp *CherryPicker) tickCmd() tea.Cmd {
 
 func (cp *CherryPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cp.handleWindowSize(msg)
+		return cp, nil
 	case tea.KeyMsg:
+		// Handle the help overlay input differently
+		if cp.helpMode {
+			return cp.handleHelpInput(msg)
+		}
+		
+		// Handle the full-screen diff viewer input differently
+		if cp.diffViewMode {
+			return cp.handleDiffViewInput(msg)
+		}
+		
 		// Handle search mode input differently
 		if cp.searchMode {
 			return cp.handleSearchInput(msg)
 		}
 		
+		// Handle protected target confirmation input differently
+		if cp.protectConfirmMode {
+			return cp.handleProtectConfirmInput(msg)
+		}
+		
+		// Handle pre-push confirmation input differently
+		if cp.pushPreviewMode {
+			return cp.handlePushPreviewInput(msg)
+		}
+		
+		// Handle failed verification input differently
+		if cp.verifyMode {
+			return cp.handleVerifyInput(msg)
+		}
+		
 		// Handle conflict mode input differently
 		if cp.conflictMode {
 			if cp.editorMode {
@@ -46,75 +74,115 @@ func (cp *CherryPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return cp.handleAuthorInput(msg)
 		}
 		
-		switch msg.String() {
-		case "ctrl+c", "q":
+		// Handle type filter input differently
+		if cp.typeFilterMode {
+			return cp.handleTypeFilterInput(msg)
+		}
+		
+		// Handle path scope input differently
+		if cp.pathMode {
+			return cp.handlePathInput(msg)
+		}
+		
+		// Handle diff search input differently
+		if cp.pickaxeMode {
+			return cp.handlePickaxeInput(msg)
+		}
+		
+		// Handle full-screen preview input differently
+		if cp.previewMode {
+			return cp.handlePreviewInput(msg)
+		}
+		
+		switch cp.keyAction(keyModeList, msg) {
+		case "quit":
 			cp.quitting = true
 			return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-		case "enter", " ":
-			commit := cp.getCurrentCommit()
-			if commit != nil && !commit.AlreadyApplied {
-				cp.selected[commit.SHA] = !cp.selected[commit.SHA]
-			}
-		case "down", "j", "n":
-			maxIndex := cp.getMaxIndex()
-			if cp.currentIndex < maxIndex && maxIndex >= 0 {
-				cp.currentIndex++
-				cp.updateRangeEnd()
-				cp.updatePreview()
-			}
-		case "up", "k":
-			if cp.currentIndex > 0 {
-				cp.currentIndex--
-				cp.updateRangeEnd()
-				cp.updatePreview()
-			}
-		case "pagedown", "ctrl+f":
+		case "toggle":
+			cp.toggleCurrentCommit()
+		case "down":
+			cp.moveCursor(1)
+		case "up":
+			cp.moveCursor(-1)
+		case "page_down":
 			// Jump down by page
-			maxIndex := cp.getMaxIndex()
-			if maxIndex >= 0 {
-				cp.currentIndex += 25
-				if cp.currentIndex > maxIndex {
-					cp.currentIndex = maxIndex
-				}
-				cp.updateRangeEnd()
-				cp.updatePreview()
-			}
-		case "pageup", "ctrl+b":
+			cp.moveCursor(cp.listPageSize())
+		case "page_up":
 			// Jump up by page
-			cp.currentIndex -= 25
-			if cp.currentIndex < 0 {
-				cp.currentIndex = 0
-			}
-			cp.updateRangeEnd()
-			cp.updatePreview()
-		case "/", "f":
+			cp.moveCursor(-cp.listPageSize())
+		case "top":
+			cp.moveCursor(-len(cp.commits))
+		case "bottom":
+			cp.moveCursor(len(cp.commits))
+		case "search":
 			// Enter search mode
 			cp.toggleSearchMode()
-		case "p", "tab":
+		case "clear_search":
+			// Clear a kept search filter
+			if cp.searchActive() {
+				cp.keepCursor(cp.clearSearch)
+			}
+		case "preview":
 			// Toggle preview mode
 			cp.togglePreviewMode()
-		case "b":
+		case "diff_viewer":
+			// Open the current commit in the full-screen diff viewer
+			cp.openDiffView()
+		case "preview_pane":
+			// Show or hide the live preview pane
+			cp.togglePreviewPane()
+		case "preview_down":
+			// Scroll the preview down
+			cp.scrollPreview(1)
+		case "preview_up":
+			// Scroll the preview up
+			cp.scrollPreview(-1)
+		case "preview_half_down":
+			// Scroll the preview down half a page
+			cp.scrollPreview(cp.previewPageSize())
+		case "preview_half_up":
+			// Scroll the preview up half a page
+			cp.scrollPreview(-cp.previewPageSize())
+		case "target_branch":
 			// Switch target branch
 			cp.enterBranchMode("target")
-		case "B":
+		case "source_branch":
 			// Switch source branch
 			cp.enterBranchMode("source")
-		case "A":
+		case "authors":
 			// Switch author
 			cp.enterAuthorMode()
-		case "r":
+		case "range":
 			// Toggle range selection mode
 			cp.toggleRangeSelection()
-		case "R":
+		case "reverse":
 			// Toggle reverse commit order
 			cp.toggleCommitOrder()
-		case "d":
+		case "detail":
 			// Toggle detail view
 			cp.detailView = !cp.detailView
-		case "H":
+		case "hide_applied":
 			// Toggle hiding applied commits
-			cp.hideApplied = !cp.hideApplied
-		case "a":
+			cp.keepCursor(func() { cp.hideApplied = !cp.hideApplied })
+		case "group_tickets":
+			// Toggle grouping by ticket
+			cp.toggleGroupByTicket()
+		case "collapse_group":
+			// Collapse or expand the current ticket group
+			cp.toggleTicketCollapse()
+		case "select_ticket":
+			// Select every commit for the current ticket
+			cp.selectTicket()
+		case "type_filter":
+			// Filter by Conventional Commits type
+			cp.enterTypeFilterMode()
+		case "diff_search":
+			// Search inside diffs (git log -S/-G)
+			cp.enterPickaxeMode()
+		case "paths":
+			// Scope the list to commits touching some paths
+			cp.enterPathMode()
+		case "select_all":
 			// Select all visible commits (except already applied ones)
 			visibleCommits := cp.getVisibleCommits()
 			for _, commit := range visibleCommits {
@@ -122,28 +190,39 @@ func (cp *CherryPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cp.selected[commit.SHA] = true
 				}
 			}
-		case "c":
+		case "clear":
 			// Clear all selections
 			cp.selected = make(map[string]bool)
-		case "m":
-			// Filter/highlight merge commits
-			// This could be implemented as a filter mode
-		case "i":
+		case "rebase":
 			// Interactive rebase selected commits
 			if len(cp.getSelectedSHAs()) > 0 {
 				cp.rebaseRequested = true
 				cp.quitting = true
 				return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
 			}
-		case "e", "x":
+		case "override_policy":
+			// Allow executing commits that violate the target's policies
+			cp.togglePolicyOverride()
+		case "execute":
+			// Refuse policy violations unless overridden
+			if len(cp.selectedViolations()) > 0 && !cp.policyOverride {
+				cp.policyBlocked = true
+				return cp, nil
+			}
+			// Protected targets must be confirmed by typing their name
+			if len(cp.getSelectedSHAs()) > 0 && cp.isProtectedTarget() && !cp.protectedConfirmed {
+				cp.enterProtectConfirmMode()
+				return cp, nil
+			}
 			// Execute cherry-pick for selected commits
 			if len(cp.getSelectedSHAs()) > 0 {
 				cp.executeRequested = true
 				cp.quitting = true
 				return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
 			}
-		case "?":
-			// Show help (could be implemented as a help overlay)
+		case "help":
+			// Show the keys of the list
+			cp.openHelp()
 		}
 	case tickMsg:
 		cp.cursorBlink = !cp.cursorBlink
@@ -154,51 +233,45 @@ func (cp *CherryPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleSearchInput handles keyboard input when in search mode
 func (cp *CherryPicker) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle special keys first (control keys that shouldn't be added to search)
-	switch msg.Type {
-	case tea.KeyCtrlC:
+	// Handle bound keys first (control keys that shouldn't be added to search)
+	switch cp.keyAction(keyModeSearch, msg) {
+	case "quit":
 		cp.quitting = true
 		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-	case tea.KeyEsc:
+	case "close":
 		// Exit search mode
 		cp.toggleSearchMode()
 		return cp, nil
-	case tea.KeyEnter:
+	case "keep":
 		// Exit search mode and keep current filter
 		cp.searchMode = false
-		if len(cp.filteredCommits) == 0 {
-			// If no results, reset to show all commits
-			cp.filteredCommits = nil
+		if strings.TrimSpace(cp.searchQuery) == "" || len(cp.searchMatches) == 0 {
+			// Nothing to keep: show all commits again
+			cp.keepCursor(cp.clearSearch)
 		}
 		return cp, nil
-	case tea.KeyBackspace:
+	case "delete":
 		// Remove last character from search query
 		if len(cp.searchQuery) > 0 {
 			cp.searchQuery = cp.searchQuery[:len(cp.searchQuery)-1]
 			cp.updateSearchResults()
 		}
 		return cp, nil
-	case tea.KeyUp:
+	case "up":
 		// Navigate up in search results (only arrow keys, not 'k')
-		if cp.currentIndex > 0 {
-			cp.currentIndex--
-			cp.updatePreview()
-		}
+		cp.moveCursor(-1)
 		return cp, nil
-	case tea.KeyDown:
+	case "down":
 		// Navigate down in search results (only arrow keys, not 'j')
-		maxIndex := cp.getMaxIndex()
-		if cp.currentIndex < maxIndex {
-			cp.currentIndex++
-			cp.updatePreview()
-		}
+		cp.moveCursor(1)
 		return cp, nil
-	case tea.KeyTab:
+	case "toggle":
 		// Toggle selection of current commit in search mode (use TAB instead of SPACE)
-		commit := cp.getCurrentCommit()
-		if commit != nil && !commit.AlreadyApplied {
-			cp.selected[commit.SHA] = !cp.selected[commit.SHA]
-		}
+		cp.toggleCurrentCommit()
+		return cp, nil
+	case "help":
+		// Show the keys of search mode
+		cp.openHelp()
 		return cp, nil
 	}
 	
@@ -212,15 +285,68 @@ func (cp *CherryPicker) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return cp, nil
 }
 
+// handlePreviewInput handles keyboard input in the full-screen preview
+func (cp *CherryPicker) handlePreviewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModePreview, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "preview":
+		// Back to the list
+		cp.togglePreviewMode()
+	case "down":
+		cp.moveCursor(1)
+	case "up":
+		cp.moveCursor(-1)
+	case "preview_down":
+		cp.scrollPreview(1)
+	case "preview_up":
+		cp.scrollPreview(-1)
+	case "preview_half_down":
+		cp.scrollPreview(cp.previewPageSize())
+	case "preview_half_up":
+		cp.scrollPreview(-cp.previewPageSize())
+	case "toggle":
+		cp.toggleCurrentCommit()
+	case "diff_viewer":
+		// Open the previewed commit in the full-screen diff viewer
+		cp.openDiffView()
+	case "help":
+		// Show the keys of the preview
+		cp.openHelp()
+	}
+	return cp, nil
+}
+
 func (cp *CherryPicker) View() string {
 	if cp.quitting {
 		return ""
 	}
 
+	if cp.helpMode {
+		return cp.renderHelpView()
+	}
+
+	if cp.diffViewMode {
+		return cp.renderDiffView()
+	}
+
 	if cp.previewMode {
 		return cp.renderPreviewView()
 	}
 	
+	if cp.pushPreviewMode {
+		return cp.renderPushPreviewView()
+	}
+	
+	if cp.verifyMode {
+		return cp.renderVerifyView()
+	}
+	
+	if cp.protectConfirmMode {
+		return cp.renderProtectConfirmView()
+	}
+	
 	if cp.conflictMode {
 		if cp.editorMode {
 			return cp.renderEditorView()
@@ -235,8 +361,18 @@ func (cp *CherryPicker) View() string {
 	if cp.authorMode {
 		return cp.renderAuthorView()
 	}
+	
+	if cp.typeFilterMode {
+		return cp.renderTypeFilterView()
+	}
+
 
+	// Header, commit list with the live preview pane, and footer, sized to the window
+	return cp.renderMainLayout()
+}
 
+// renderListHeader renders the branches, active filters, prompts and the list title
+func (cp *CherryPicker) renderListHeader(visibleCommits []Commit) string {
 	var s strings.Builder
 
 	s.WriteString("📝 Cherry Pick Commits\n\n")
@@ -245,170 +381,240 @@ func (cp *CherryPicker) View() string {
 	s.WriteString(fmt.Sprintf("🌿 Cherry-picking from %s → %s\n", 
 		cp.config.Git.SourceBranch, 
 		cp.config.Git.TargetBranch))
-	s.WriteString(fmt.Sprintf("👤 Author Filter: %s\n\n", cp.selectedAuthor))
+	if cp.isProtectedTarget() {
+		s.WriteString(fmt.Sprintf("🔒 %s is protected: executing requires typing its name\n", cp.config.Git.TargetBranch))
+	}
+	s.WriteString(fmt.Sprintf("👤 Author Filter: %s\n", cp.authorFilter.label()))
+	if cp.typeFilterActive() {
+		s.WriteString(fmt.Sprintf("🏷️  Type Filter: %s\n", cp.typeFilterLabel()))
+	}
+	if len(cp.paths) > 0 {
+		s.WriteString(fmt.Sprintf("🗂️  Paths: %s\n", cp.pathsLabel()))
+	}
+	if cp.pickaxeActive() {
+		s.WriteString(fmt.Sprintf("🔎 Diff Search: %s (%d commits)\n", cp.pickaxeLabel(), len(cp.pickaxeMatches)))
+	}
+	s.WriteString("\n")
+	
+	// Show the path scope prompt
+	if cp.pathMode {
+		s.WriteString(cp.renderPathPrompt())
+	}
+	
+	// Show the diff search prompt
+	if cp.pickaxeMode {
+		s.WriteString(cp.renderPickaxePrompt())
+	}
 	
 	// Show search interface if in search mode
 	if cp.searchMode {
 		s.WriteString("🔍 Search: " + cp.searchQuery + "█\n")
-		s.WriteString("(ESC=exit search, ENTER=keep filter, ↑↓=navigate, TAB=toggle)\n\n")
-		if len(cp.filteredCommits) == 0 && cp.searchQuery != "" {
-			s.WriteString("No commits match your search.\n")
-			return s.String()
+		if cp.searchError != "" {
+			s.WriteString("⚠️  " + cp.searchError + "\n")
+		} else if cp.searchFilter != nil && !cp.searchFilter.Empty() {
+			s.WriteString("🧩 " + cp.searchFilter.String() + "\n")
 		}
+		s.WriteString("(" + strings.Join(cp.controlItems(keyModeSearch), ", ") + "; e.g. author:alice path:api/ -merge size:<200)\n\n")
+	} else if cp.searchActive() {
+		s.WriteString(fmt.Sprintf("🔍 Search: %s (press %s to edit, %s to clear)\n\n", cp.searchQuery,
+			cp.keyFor(keyModeList, "search"), cp.keyFor(keyModeList, "clear_search")))
 	}
-	
+
 	// Show appropriate title
-	if cp.searchMode && cp.searchQuery != "" {
-		s.WriteString(fmt.Sprintf("Filtered commits (%d results):\n", len(cp.filteredCommits)))
+	if cp.searchActive() && cp.searchQuery != "" {
+		if len(visibleCommits) == 0 {
+			s.WriteString("No commits match your search.\n")
+		} else {
+			s.WriteString(fmt.Sprintf("Filtered commits (%d results):\n", len(visibleCommits)))
+		}
 	} else {
 		s.WriteString("Available commits:\n")
 	}
+	
+	return s.String()
+}
 
-	// Get commits to display (filtered or all)
-	visibleCommits := cp.getVisibleCommits()
+// renderCommitRow renders a visible commit, with its ticket group header if it starts a group
+// or the window (at startIndex) and its detail lines. inRange marks rows of the range selection.
+func (cp *CherryPicker) renderCommitRow(visibleCommits []Commit, i, startIndex int, inRange bool) string {
+	var s strings.Builder
+	commit := visibleCommits[i]
 	
-	// Pagination settings
-	maxCommitsPerPage := 25
-	startIndex := 0
-	endIndex := len(visibleCommits)
+	// Show a header above each ticket group
+	if cp.groupByTicket && (i == startIndex || ticketGroup(visibleCommits[i-1]) != ticketGroup(commit)) {
+		s.WriteString(cp.renderTicketHeader(ticketGroup(commit)))
+	}
 	
-	// Calculate pagination based on current cursor position
-	if len(visibleCommits) > maxCommitsPerPage {
-		// Ensure cursor is within bounds
-		if cp.currentIndex >= len(visibleCommits) {
-			cp.currentIndex = len(visibleCommits) - 1
-		}
-		if cp.currentIndex < 0 {
-			cp.currentIndex = 0
-		}
-		
-		// Calculate which page the cursor should be on
-		cursorPage := cp.currentIndex / maxCommitsPerPage
-		startIndex = cursorPage * maxCommitsPerPage
-		endIndex = startIndex + maxCommitsPerPage
-		if endIndex > len(visibleCommits) {
-			endIndex = len(visibleCommits)
-		}
-		
-		// Ensure the cursor is visible on the current page
-		if cp.currentIndex < startIndex || cp.currentIndex >= endIndex {
-			// Recalculate page to ensure cursor is visible
-			cursorPage = cp.currentIndex / maxCommitsPerPage
-			startIndex = cursorPage * maxCommitsPerPage
-			endIndex = startIndex + maxCommitsPerPage
-			if endIndex > len(visibleCommits) {
-				endIndex = len(visibleCommits)
+	cursor := "  "
+	checkbox := "[ ]"
+	commitText := cp.highlightSearchMatches(commit)
+	
+	// Range selection highlighting
+	if inRange {
+		cursor = "📍"
+	}
+
+	// Handle already applied commits
+	if commit.AlreadyApplied {
+		checkbox = "[✗]"
+		// Add strikethrough and dim styling for already applied commits
+		commitText = "\033[9m\033[2m" + commitText + "\033[0m"
+	} else if cp.selected[commit.SHA] {
+		checkbox = "[✓]"
+		// Add strikethrough to selected commits
+		commitText = "\033[9m" + commitText + "\033[0m"
+	}
+
+	// Highlight current cursor position - use actual index not display index
+	if i == cp.currentIndex {
+		cursor = "→ "
+		// Make the entire line highlighted for better visibility
+		if cp.cursorBlink {
+			if commit.AlreadyApplied {
+				checkbox = "[✗]" // No blinking for already applied
+			} else if cp.selected[commit.SHA] {
+				checkbox = "[█]"
+			} else {
+				checkbox = "[█]"
 			}
 		}
+		// Add background highlighting to the entire line
+		commitText = "\033[7m" + commitText + "\033[0m"
+	}
+
+	// Add merge commit indicator
+	mergeIndicator := ""
+	if commit.IsMerge {
+		mergeIndicator = " 🔀"
 	}
 	
-	// Show pagination info if needed
-	if len(visibleCommits) > maxCommitsPerPage {
-		currentPage := (cp.currentIndex / maxCommitsPerPage) + 1
-		totalPages := (len(visibleCommits) + maxCommitsPerPage - 1) / maxCommitsPerPage
-		s.WriteString(fmt.Sprintf("Page %d of %d (showing %d-%d of %d commits)\n", 
-			currentPage, totalPages, startIndex+1, endIndex, len(visibleCommits)))
+	// Note backports of this commit to other branches
+	if others := cp.otherBackports(commit); len(others) > 0 {
+		var targets []string
+		for _, backport := range others {
+			targets = append(targets, backport.Target)
+		}
+		mergeIndicator += " ↪ " + strings.Join(targets, ", ")
 	}
 	
-	// Display commits for current page
-	for i := startIndex; i < endIndex; i++ {
-		commit := visibleCommits[i]
-		cursor := "  "
-		checkbox := "[ ]"
-		commitText := commit.Full
-		
-		// Range selection highlighting
-		if cp.isInRange(i) {
-			cursor = "📍"
+	// Mark commits the target's policies forbid
+	if len(commit.PolicyViolations) > 0 {
+		mergeIndicator += " ⛔"
+	}
+	
+	// Show how often the commit added and removed the diff search term
+	mergeIndicator += cp.pickaxeMarker(commit)
+	
+	// Note hidden commits of a collapsed ticket group
+	if cp.groupByTicket && cp.collapsedTickets[ticketGroup(commit)] {
+		if hidden := len(cp.getTicketCommits(ticketGroup(commit))) - 1; hidden > 0 {
+			mergeIndicator += fmt.Sprintf(" (+%d more)", hidden)
 		}
+	}
 
-		// Handle already applied commits
-		if commit.AlreadyApplied {
-			checkbox = "[✗]"
-			// Add strikethrough and dim styling for already applied commits
-			commitText = "\033[9m\033[2m" + commit.Full + "\033[0m"
-		} else if cp.selected[commit.SHA] {
-			checkbox = "[✓]"
-			// Add strikethrough to selected commits
-			commitText = "\033[9m" + commit.Full + "\033[0m"
+	// Enhanced display with metadata if detail view is enabled
+	if cp.detailView {
+		dateStr := ""
+		if !commit.Date.IsZero() {
+			dateStr = commit.Date.Format("2006-01-02")
 		}
-
-		// Highlight current cursor position - use actual index not display index
-		if i == cp.currentIndex {
-			cursor = "→ "
-			// Make the entire line highlighted for better visibility
-			if cp.cursorBlink {
-				if commit.AlreadyApplied {
-					checkbox = "[✗]" // No blinking for already applied
-				} else if cp.selected[commit.SHA] {
-					checkbox = "[█]"
-				} else {
-					checkbox = "[█]"
-				}
-			}
-			// Add background highlighting to the entire line
-			commitText = "\033[7m" + commitText + "\033[0m"
+		
+		statsStr := ""
+		if commit.Insertions > 0 || commit.Deletions > 0 {
+			statsStr = fmt.Sprintf(" (+%d -%d)", commit.Insertions, commit.Deletions)
 		}
-
-		// Add merge commit indicator
-		mergeIndicator := ""
-		if commit.IsMerge {
-			mergeIndicator = " 🔀"
+		
+		// Only count the files inside the path scope, like the preview does
+		files := commit.FilesChanged
+		if len(cp.paths) > 0 {
+			files = commit.ScopedFiles
 		}
-
-		// Enhanced display with metadata if detail view is enabled
-		if cp.detailView {
-			dateStr := ""
-			if !commit.Date.IsZero() {
-				dateStr = commit.Date.Format("2006-01-02")
-			}
-			
-			statsStr := ""
-			if commit.Insertions > 0 || commit.Deletions > 0 {
-				statsStr = fmt.Sprintf(" (+%d -%d)", commit.Insertions, commit.Deletions)
-			}
-			
-			filesStr := ""
-			if len(commit.FilesChanged) > 0 {
-				if len(commit.FilesChanged) == 1 {
-					filesStr = fmt.Sprintf(" [%s]", commit.FilesChanged[0])
-				} else {
-					filesStr = fmt.Sprintf(" [%d files]", len(commit.FilesChanged))
-				}
+		filesStr := ""
+		if len(files) > 0 {
+			if len(files) == 1 {
+				filesStr = fmt.Sprintf(" [%s]", files[0])
+			} else {
+				filesStr = fmt.Sprintf(" [%d files]", len(files))
 			}
-			
-			s.WriteString(fmt.Sprintf("%s%s %s%s%s%s\n", cursor, checkbox, commitText, mergeIndicator, statsStr, filesStr))
-			if dateStr != "" || commit.Author != "" {
-				s.WriteString(fmt.Sprintf("    📅 %s 👤 %s\n", dateStr, commit.Author))
+		}
+		
+		s.WriteString(fmt.Sprintf("%s%s %s%s%s%s%s\n", cursor, checkbox, cp.renderTypeBadge(commit), commitText, mergeIndicator, statsStr, filesStr))
+		if dateStr != "" || commit.Author != "" {
+			ticketStr := ""
+			if len(commit.Tickets) > 0 {
+				ticketStr = " 🎫 " + strings.Join(commit.Tickets, ", ")
 			}
-		} else {
-			s.WriteString(fmt.Sprintf("%s%s %s%s\n", cursor, checkbox, commitText, mergeIndicator))
+			s.WriteString(fmt.Sprintf("    📅 %s 👤 %s%s\n", dateStr, commit.Author, ticketStr))
+		}
+		if len(commit.PolicyViolations) > 0 {
+			s.WriteString(fmt.Sprintf("    ⛔ %s\n", strings.Join(commit.PolicyViolations, "; ")))
 		}
+	} else {
+		s.WriteString(fmt.Sprintf("%s%s %s%s%s\n", cursor, checkbox, cp.renderTypeBadge(commit), commitText, mergeIndicator))
 	}
+	return s.String()
+}
 
-	s.WriteString("\n")
-	s.WriteString(cp.getSelectedCommitsDisplay())
+// renderListFooter renders policy warnings, the selected commits, the status line and the
+// controls wrapped to width. A compact footer lists fewer selected commits and cuts the
+// controls to one line.
+func (cp *CherryPicker) renderListFooter(width int, compact bool) string {
+	selectedLines, controls := maxSelectedLines, wrapText(cp.getControlsDisplay(), width)
+	if compact {
+		selectedLines = 1
+		controls = fitLines([]string{cp.getControlsDisplay()}, width, 1)[0]
+	}
+
+	var s strings.Builder
+	for _, warning := range cp.policyWarnings() {
+		s.WriteString("⚠️  " + warning + "\n")
+	}
+	if violating := cp.selectedViolations(); len(violating) > 0 {
+		if cp.policyOverride {
+			s.WriteString(fmt.Sprintf("⛔ %d selected commit(s) violate the %s policy; override is ON and will be recorded\n",
+				len(violating), cp.config.Git.TargetBranch))
+		} else if cp.policyBlocked {
+			s.WriteString(fmt.Sprintf("⛔ Refusing to execute: %d selected commit(s) violate the %s policy. Deselect them or press %s to override.\n",
+				len(violating), cp.config.Git.TargetBranch, cp.keyFor(keyModeList, "override_policy")))
+		} else {
+			s.WriteString(fmt.Sprintf("⛔ %d selected commit(s) violate the %s policy\n", len(violating), cp.config.Git.TargetBranch))
+		}
+	}
+	s.WriteString(cp.getSelectedCommitsDisplay(selectedLines))
 	s.WriteString("\n")
 	s.WriteString(cp.getStatusLine())
 	s.WriteString("\n")
-	s.WriteString(cp.getControlsDisplay())
+	s.WriteString(controls)
 
 	return s.String()
 }
 
-// renderPreviewView renders the commit preview interface
+// renderPreviewView renders the full-screen commit preview, sized to the window
 func (cp *CherryPicker) renderPreviewView() string {
+	width, height := cp.windowSize()
 	var s strings.Builder
 	
 	s.WriteString("📖 Commit Preview\n")
-	s.WriteString("═══════════════════════════════════════════════════════════════════════════════\n\n")
+	s.WriteString(strings.Repeat("═", width) + "\n")
 	
 	if cp.previewCommit == nil {
-		s.WriteString("No commit selected for preview.\n")
-		s.WriteString("\nPress 'p' or TAB to exit preview mode.")
+		s.WriteString("\nNo commit selected for preview.\n")
+		s.WriteString("\nPress " + cp.keyFor(keyModePreview, "preview") + " to exit preview mode.")
 		return s.String()
 	}
 	
+	// Controls
+	controls := wrapText(cp.controlsLine(keyModePreview), width)
+	s.WriteString(cp.renderPreviewPane(width, height-2-lineCount(controls)))
+	s.WriteString("\n" + controls)
+	
+	return s.String()
+}
+
+// previewContent renders the whole preview of the preview commit: metadata, statistics and the
+// diff, or only the hunks matching the diff search
+func (cp *CherryPicker) previewContent() string {
+	var s strings.Builder
 	commit := cp.previewCommit
 	
 	// Header with commit info
@@ -432,6 +638,12 @@ func (cp *CherryPicker) renderPreviewView() string {
 	if commit.Author != "" {
 		s.WriteString(fmt.Sprintf("👤 Author: %s\n", commit.Author))
 	}
+	for _, backport := range commit.Backports {
+		s.WriteString(fmt.Sprintf("↪️  Backported to %s\n", formatBackport(backport)))
+	}
+	for _, violation := range commit.PolicyViolations {
+		s.WriteString(fmt.Sprintf("⛔ Policy: %s\n", violation))
+	}
 	s.WriteString("\n")
 	
 	// Statistics
@@ -440,98 +652,145 @@ func (cp *CherryPicker) renderPreviewView() string {
 		s.WriteString("\n")
 	}
 	
-	// Diff preview (truncated)
-	if cp.previewDiff != "" {
-		s.WriteString("🔍 Diff Preview:\n")
-		s.WriteString("───────────────────────────────────────────────────────────────────────────────\n")
-		
-		// Truncate diff if too long
-		diffLines := strings.Split(cp.previewDiff, "\n")
-		maxLines := 20 // Show first 20 lines of diff
-		
-		for i, line := range diffLines {
-			if i >= maxLines {
-				s.WriteString(fmt.Sprintf("... (%d more lines) ...\n", len(diffLines)-maxLines))
-				break
-			}
-			
-			// Add color coding for diff lines
-			if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-				s.WriteString("\033[32m" + line + "\033[0m\n") // Green for additions
-			} else if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
-				s.WriteString("\033[31m" + line + "\033[0m\n") // Red for deletions
-			} else if strings.HasPrefix(line, "@@") {
-				s.WriteString("\033[36m" + line + "\033[0m\n") // Cyan for hunk headers
-			} else {
-				s.WriteString(line + "\n")
-			}
-		}
-		s.WriteString("───────────────────────────────────────────────────────────────────────────────\n\n")
+	// Hunks matching the diff search, or the whole diff
+	var diffLines []string
+	if _, ok := cp.pickaxeMatches[commit.SHA]; ok && cp.previewDiff != "" {
+		diffLines = cp.pickaxeHunks(cp.previewDiff)
+	}
+	if len(diffLines) > 0 {
+		s.WriteString(fmt.Sprintf("🔎 Hunks matching %s:\n", cp.pickaxeLabel()))
+	} else if cp.previewDiff != "" {
+		s.WriteString("🔍 Diff:\n")
+		diffLines = strings.Split(strings.TrimRight(cp.previewDiff, "\n"), "\n")
+	}
+	for _, line := range diffLines {
+		s.WriteString(colorDiffLine(line) + "\n")
 	}
-	
-	// Controls
-	s.WriteString("Controls: p/TAB=exit preview, ↑↓=navigate commits, SPACE=toggle selection, q=quit\n")
 	
 	return s.String()
 }
 
+// colorDiffLine colors added and removed lines and hunk headers of a diff
+func colorDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
+		return "\033[32m" + line + "\033[0m" // Green for additions
+	case strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
+		return "\033[31m" + line + "\033[0m" // Red for deletions
+	case strings.HasPrefix(line, "@@"):
+		return "\033[36m" + line + "\033[0m" // Cyan for hunk headers
+	}
+	return line
+}
+
 // handleConflictInput handles keyboard input when in conflict resolution mode
 func (cp *CherryPicker) handleConflictInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch cp.keyAction(keyModeConflict, msg) {
+	case "quit":
 		cp.quitting = true
 		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-	case "esc":
+	case "close":
 		// Exit conflict mode without action
 		cp.exitConflictMode()
-	case "c":
+	case "continue":
 		// Continue cherry-pick (if all conflicts resolved)
 		if err := cp.continueConflictResolution(); err != nil {
 			// Still have conflicts, stay in conflict mode
 			cp.loadConflictFiles()
 		} else {
-			// Success, exit conflict mode
-			cp.exitConflictMode()
+			// Success, go back to the run
+			cp.conflictDecision = "continue"
+			cp.quitting = true
+			return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
 		}
-	case "a":
-		// Abort cherry-pick
+	case "abort":
+		// Abort cherry-pick and end the run
 		if err := cp.abortConflictResolution(); err == nil {
-			cp.exitConflictMode()
+			cp.conflictDecision = "abort"
+			cp.quitting = true
+			return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
 		}
-	case "s":
-		// Skip this commit
+	case "skip":
+		// Skip this commit and go on with the run
 		if err := cp.skipConflictResolution(); err == nil {
-			cp.exitConflictMode()
+			cp.conflictDecision = "skip"
+			cp.quitting = true
+			return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
 		}
-	case "1":
+	case "editor":
 		// Enter editor selection mode
 		cp.enterEditorMode()
-	case "2":
-		// Skip this commit
-		if err := cp.skipConflictResolution(); err == nil {
-			cp.exitConflictMode()
-		}
-	case "3":
-		// Abort cherry-pick
-		if err := cp.abortConflictResolution(); err == nil {
-			cp.exitConflictMode()
-		}
-	case "4":
-		// Continue after manual resolution
-		if err := cp.continueConflictResolution(); err != nil {
-			// Still have conflicts, stay in conflict mode
-			cp.loadConflictFiles()
-		} else {
-			// Success, exit conflict mode
-			cp.exitConflictMode()
-		}
-	case "r":
+	case "refresh":
 		// Refresh conflict status
 		cp.loadConflictFiles()
+	case "help":
+		// Show the keys of conflict resolution
+		cp.openHelp()
 	}
 	return cp, nil
 }
 
+// handleVerifyInput handles keyboard input after a failed verification
+func (cp *CherryPicker) handleVerifyInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch cp.keyAction(keyModeVerify, msg) {
+	case "stop":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "revert":
+		// Revert the pick that failed verification
+		cp.verifyDecision = "revert"
+	case "continue":
+		// Keep the pick and continue with the next commit
+		cp.verifyDecision = "continue"
+	case "abort":
+		// Abort and roll back the whole run
+		cp.verifyDecision = "abort"
+	case "help":
+		// Show the keys of the failed verification screen
+		cp.openHelp()
+		return cp, nil
+	default:
+		return cp, nil
+	}
+	return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+}
+
+// renderVerifyView renders the failed verification interface
+func (cp *CherryPicker) renderVerifyView() string {
+	var s strings.Builder
+	
+	s.WriteString("🧪 Verification Failed\n")
+	s.WriteString("═══════════════════════════════════════════════════════════════════════════════\n\n")
+	
+	s.WriteString(fmt.Sprintf("Command: %s\n", cp.config.Behavior.VerifyCommand))
+	if cp.verifyCommit != "" {
+		s.WriteString(fmt.Sprintf("After picking: %s\n\n", cp.verifyCommit))
+	} else {
+		s.WriteString(fmt.Sprintf("After picking all %d commits\n\n", len(cp.pickedSHAs)))
+	}
+	
+	s.WriteString("📄 Output:\n")
+	s.WriteString("───────────────────────────────────────────────────────────────────────────────\n")
+	for _, line := range cp.verifyOutputTail(30) {
+		s.WriteString(line + "\n")
+	}
+	s.WriteString("───────────────────────────────────────────────────────────────────────────────\n\n")
+	
+	s.WriteString("🔧 Options:\n")
+	if cp.verifyCommit != "" {
+		s.WriteString(fmt.Sprintf("• %s = Revert this pick and continue\n", cp.keyFor(keyModeVerify, "revert")))
+		s.WriteString(fmt.Sprintf("• %s = Keep this pick and continue\n", cp.keyFor(keyModeVerify, "continue")))
+	} else {
+		s.WriteString(fmt.Sprintf("• %s = Revert all picks of this run\n", cp.keyFor(keyModeVerify, "revert")))
+		s.WriteString(fmt.Sprintf("• %s = Keep the picks and finish the run\n", cp.keyFor(keyModeVerify, "continue")))
+	}
+	s.WriteString(fmt.Sprintf("• %s = Abort the run and roll back\n", cp.keyFor(keyModeVerify, "abort")))
+	s.WriteString(fmt.Sprintf("• %s = Stop here and leave the picks in place\n", cp.keyFor(keyModeVerify, "stop")))
+	s.WriteString(fmt.Sprintf("• %s = Show all keys\n", cp.keyFor(keyModeVerify, "help")))
+	
+	return s.String()
+}
+
 // showFileResolutionOptions shows resolution options for a specific file
 func (cp *CherryPicker) showFileResolutionOptions(fileIndex int) {
 	// This would typically open a sub-menu or prompt
@@ -558,7 +817,8 @@ func (cp *CherryPicker) renderConflictView() string {
 	
 	if len(cp.conflictFiles) == 0 {
 		s.WriteString("✅ No conflicts detected. You can continue the cherry-pick.\n\n")
-		s.WriteString("Press 'c' to continue, 'a' to abort, or 's' to skip this commit.\n")
+		s.WriteString(fmt.Sprintf("Press %s to continue, %s to abort, or %s to skip this commit.\n",
+			cp.keyFor(keyModeConflict, "continue"), cp.keyFor(keyModeConflict, "abort"), cp.keyFor(keyModeConflict, "skip")))
 		return s.String()
 	}
 	
@@ -586,47 +846,51 @@ func (cp *CherryPicker) renderConflictView() string {
 	
 	// Resolution options
 	s.WriteString("🔧 Resolution Options:\n")
-	s.WriteString("• 1 = Choose editor to resolve conflicts\n")
-	s.WriteString("• 2 = Skip this commit\n")
-	s.WriteString("• 3 = Abort cherry-pick\n")
-	s.WriteString("• 4 = Continue after manual resolution\n")
-	s.WriteString("• r = Refresh conflict status\n")
-	s.WriteString("• ESC = Exit conflict mode\n\n")
+	s.WriteString(cp.keyOptions(keyModeConflict))
+	s.WriteString("\n")
 	
-	s.WriteString("💡 Tip: Resolve conflicts manually in your editor, then press 'r' to refresh\n")
-	s.WriteString("    and '4' to continue when all conflicts are resolved.\n")
+	s.WriteString(fmt.Sprintf("💡 Tip: Resolve conflicts manually in your editor, then press %s to refresh\n", cp.keyFor(keyModeConflict, "refresh")))
+	s.WriteString(fmt.Sprintf("    and %s to continue when all conflicts are resolved.\n", cp.keyFor(keyModeConflict, "continue")))
 	
 	return s.String()
 }
 
 // handleBranchInput handles keyboard input when in branch selection mode
 func (cp *CherryPicker) handleBranchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch cp.keyAction(keyModeBranch, msg) {
+	case "quit":
 		cp.quitting = true
 		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-	case "esc":
+	case "close":
 		// Exit branch mode without changes
 		cp.exitBranchMode()
-	case "enter":
+	case "choose":
 		// Select the current branch and reload commits
 		if err := cp.selectBranch(); err != nil {
 			// Handle error, but for now just exit branch mode
 			cp.exitBranchMode()
 		}
-	case "down", "j":
+	case "down":
 		// Navigate down in branch list
 		if cp.branchIndex < len(cp.availableBranches)-1 {
 			cp.branchIndex++
 		}
-	case "up", "k":
+	case "up":
 		// Navigate up in branch list
 		if cp.branchIndex > 0 {
 			cp.branchIndex--
 		}
-	case "r":
+	case "refresh":
 		// Refresh branch list
 		cp.loadAvailableBranches()
+	case "branch_scope":
+		// Cycle between local, remote and all branches
+		cp.branchScope = nextBranchScope(cp.branchScope)
+		cp.branchIndex = 0
+		cp.loadAvailableBranches()
+	case "help":
+		// Show the keys of branch selection
+		cp.openHelp()
 	}
 	return cp, nil
 }
@@ -638,31 +902,37 @@ func (cp *CherryPicker) handleAuthorInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return cp.handleAuthorSearchInput(msg)
 	}
 	
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch cp.keyAction(keyModeAuthor, msg) {
+	case "quit":
 		cp.quitting = true
 		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-	case "esc":
+	case "close":
 		// Exit author mode without changes
 		cp.exitAuthorMode()
-	case " ":
-		// Select the current author and reload commits
-		if err := cp.selectAuthor(); err != nil {
+	case "toggle":
+		// Toggle the author, team or all-authors entry under the cursor
+		cp.toggleAuthor()
+	case "all_authors":
+		// Toggle all-authors mode
+		cp.authorDraft.All = !cp.authorDraft.All
+	case "apply":
+		// Apply the selection and reload commits
+		if err := cp.applyAuthors(); err != nil {
 			// Handle error, but for now just exit author mode
 			cp.exitAuthorMode()
 		}
-	case "down", "j":
+	case "down":
 		// Navigate down in author list
 		visibleAuthors := cp.getVisibleAuthors()
 		if cp.authorIndex < len(visibleAuthors)-1 {
 			cp.authorIndex++
 		}
-	case "up", "k":
+	case "up":
 		// Navigate up in author list
 		if cp.authorIndex > 0 {
 			cp.authorIndex--
 		}
-	case "pagedown", "ctrl+f":
+	case "page_down":
 		// Jump down by page in author list
 		visibleAuthors := cp.getVisibleAuthors()
 		maxIndex := len(visibleAuthors) - 1
@@ -670,36 +940,39 @@ func (cp *CherryPicker) handleAuthorInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if cp.authorIndex > maxIndex {
 			cp.authorIndex = maxIndex
 		}
-	case "pageup", "ctrl+b":
+	case "page_up":
 		// Jump up by page in author list
 		cp.authorIndex -= 10
 		if cp.authorIndex < 0 {
 			cp.authorIndex = 0
 		}
-	case "/", "f":
+	case "search":
 		// Enter author search mode
 		cp.toggleAuthorSearchMode()
-	case "r":
+	case "refresh":
 		// Refresh author list
 		if err := cp.getAvailableAuthors(); err != nil {
 			// Handle error - keep current list
 		}
+	case "help":
+		// Show the keys of author selection
+		cp.openHelp()
 	}
 	return cp, nil
 }
 
 // handleAuthorSearchInput handles keyboard input when in author search mode
 func (cp *CherryPicker) handleAuthorSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Handle special keys first (control keys that shouldn't be added to search)
-	switch msg.Type {
-	case tea.KeyCtrlC:
+	// Handle bound keys first (control keys that shouldn't be added to search)
+	switch cp.keyAction(keyModeAuthorSearch, msg) {
+	case "quit":
 		cp.quitting = true
 		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-	case tea.KeyEsc:
+	case "close":
 		// Exit search mode
 		cp.toggleAuthorSearchMode()
 		return cp, nil
-	case tea.KeyEnter:
+	case "keep":
 		// Exit search mode and keep current filter
 		cp.authorSearchMode = false
 		if len(cp.filteredAuthors) == 0 {
@@ -707,31 +980,33 @@ func (cp *CherryPicker) handleAuthorSearchInput(msg tea.KeyMsg) (tea.Model, tea.
 			cp.filteredAuthors = nil
 		}
 		return cp, nil
-	case tea.KeyBackspace:
+	case "delete":
 		// Remove last character from search query
 		if len(cp.authorSearchQuery) > 0 {
 			cp.authorSearchQuery = cp.authorSearchQuery[:len(cp.authorSearchQuery)-1]
 			cp.updateAuthorSearchResults()
 		}
 		return cp, nil
-	case tea.KeyUp:
+	case "up":
 		// Navigate up in search results (only arrow keys, not 'k')
 		if cp.authorIndex > 0 {
 			cp.authorIndex--
 		}
 		return cp, nil
-	case tea.KeyDown:
+	case "down":
 		// Navigate down in search results (only arrow keys, not 'j')
 		visibleAuthors := cp.getVisibleAuthors()
 		if cp.authorIndex < len(visibleAuthors)-1 {
 			cp.authorIndex++
 		}
 		return cp, nil
-	case tea.KeySpace:
-		// Select current author in search mode
-		if err := cp.selectAuthor(); err != nil {
-			cp.exitAuthorMode()
-		}
+	case "toggle":
+		// Toggle the current entry in search mode
+		cp.toggleAuthor()
+		return cp, nil
+	case "help":
+		// Show the keys of author search
+		cp.openHelp()
 		return cp, nil
 	}
 	
@@ -766,11 +1041,11 @@ func (cp *CherryPicker) renderBranchView() string {
 	
 	if len(cp.availableBranches) == 0 {
 		s.WriteString("❌ No available branches found.\n\n")
-		s.WriteString("Press ESC to go back or 'r' to refresh.\n")
+		s.WriteString(fmt.Sprintf("Press %s to go back or %s to refresh.\n", cp.keyFor(keyModeBranch, "close"), cp.keyFor(keyModeBranch, "refresh")))
 		return s.String()
 	}
 	
-	s.WriteString(fmt.Sprintf("🌿 Select New %s Branch:\n", switchType))
+	s.WriteString(fmt.Sprintf("🌿 Select New %s Branch (%s branches):\n", switchType, normalizeBranchScope(cp.branchScope)))
 	s.WriteString("───────────────────────────────────────────────────────────────────────────────\n")
 	
 	for i, branch := range cp.availableBranches {
@@ -781,23 +1056,24 @@ func (cp *CherryPicker) renderBranchView() string {
 		
 		// Highlight current target/source branch
 		current := ""
-		if cp.branchSwitchType == "target" && branch == cp.config.Git.TargetBranch {
+		if cp.branchSwitchType == "target" && branch.Name == cp.config.Git.TargetBranch {
 			current = " (current target)"
-		} else if cp.branchSwitchType == "source" && branch == cp.config.Git.SourceBranch {
+		} else if cp.branchSwitchType == "source" && branch.Name == cp.config.Git.SourceBranch {
 			current = " (current source)"
 		}
+		if cp.branchSwitchType == "target" && matchesBranchPattern(cp.config.Git.ExcludedBranches, branch.Name) {
+			current += " 🔒"
+		}
 		
-		s.WriteString(fmt.Sprintf("%s%s%s\n", cursor, branch, current))
+		s.WriteString(fmt.Sprintf("%s%s%s%s\n", cursor, branch.Name, branchDetails(branch), current))
 	}
 	
 	s.WriteString("───────────────────────────────────────────────────────────────────────────────\n\n")
 	
 	// Instructions
 	s.WriteString("🔧 Controls:\n")
-	s.WriteString("• ↑↓/k j = Navigate branches\n")
-	s.WriteString("• ENTER = Select branch and reload commits\n")
-	s.WriteString("• r = Refresh branch list\n")
-	s.WriteString("• ESC = Cancel and go back\n\n")
+	s.WriteString(cp.keyOptions(keyModeBranch))
+	s.WriteString("\n")
 	
 	s.WriteString("💡 Tip: Selecting a new branch will reload the commit list and clear current selections.\n")
 	
@@ -814,12 +1090,12 @@ func (cp *CherryPicker) renderAuthorView() string {
 	s.WriteString(fmt.Sprintf("🌿 Cherry-picking from %s → %s\n", 
 		cp.config.Git.SourceBranch, 
 		cp.config.Git.TargetBranch))
-	s.WriteString(fmt.Sprintf("👤 Current Author Filter: %s\n\n", cp.selectedAuthor))
+	s.WriteString(fmt.Sprintf("👤 Current Author Filter: %s\n\n", cp.authorFilter.label()))
 	
 	// Show search interface if in search mode
 	if cp.authorSearchMode {
 		s.WriteString("🔍 Search Authors: " + cp.authorSearchQuery + "█\n")
-		s.WriteString("(ESC=exit search, ENTER=keep filter, ↑↓=navigate, SPACE=select)\n\n")
+		s.WriteString("(" + strings.Join(cp.controlItems(keyModeAuthorSearch), ", ") + ")\n\n")
 		if len(cp.filteredAuthors) == 0 && cp.authorSearchQuery != "" {
 			s.WriteString("No authors match your search.\n")
 			return s.String()
@@ -832,7 +1108,8 @@ func (cp *CherryPicker) renderAuthorView() string {
 		s.WriteString("No authors found.\n")
 		s.WriteString("\n")
 		s.WriteString("Status: No authors available\n")
-		s.WriteString("Controls: ESC=go back, r=refresh, q=quit\n")
+		s.WriteString(fmt.Sprintf("Controls: %s=go back, %s=refresh, %s=quit\n",
+			cp.keyFor(keyModeAuthor, "close"), cp.keyFor(keyModeAuthor, "refresh"), cp.keyFor(keyModeAuthor, "quit")))
 		return s.String()
 	}
 
@@ -867,12 +1144,13 @@ func (cp *CherryPicker) renderAuthorView() string {
 	for i := startIndex; i < endIndex; i++ {
 		author := visibleAuthors[i]
 		cursor := "  "
-		authorText := author
 		
-		// Mark current selected author
-		if author == cp.selectedAuthor {
-			authorText = "✓ " + author + " (current)"
+		// Mark selected authors, teams and all-authors mode
+		checkbox := "[ ] "
+		if cp.authorDraft.selects(author) {
+			checkbox = "[✓] "
 		}
+		authorText := checkbox + author.label()
 
 		// Highlight current cursor position with background
 		if i == cp.authorIndex {
@@ -885,17 +1163,88 @@ func (cp *CherryPicker) renderAuthorView() string {
 	}
 
 	s.WriteString("\n")
-	s.WriteString(fmt.Sprintf("Selected author: %s\n", cp.selectedAuthor))
+	s.WriteString(fmt.Sprintf("Selection: %s\n", cp.authorDraft.label()))
 	s.WriteString("\n")
 	s.WriteString("Status: Ready\n")
 	
-	// Show appropriate controls based on pagination
-	if len(cp.availableAuthors) > maxAuthorsPerPage {
-		s.WriteString("Controls: ↑↓/j k=navigate, PgUp/PgDn=page, SPACE=select, /=search, ESC=cancel, q=quit\n")
-	} else {
-		s.WriteString("Controls: ↑↓/j k=navigate, SPACE=select, /=search, ESC=cancel, q=quit\n")
+	s.WriteString(cp.controlsLine(keyModeAuthor) + "\n")
+
+	return s.String()
+}
+
+// handleTypeFilterInput handles keyboard input when in type filter mode
+func (cp *CherryPicker) handleTypeFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	types, _ := cp.getCommitTypes()
+	
+	switch cp.keyAction(keyModeTypeFilter, msg) {
+	case "quit":
+		cp.quitting = true
+		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
+	case "close":
+		// Close the filter; toggles apply immediately
+		cp.exitTypeFilterMode()
+	case "toggle":
+		if cp.typeIndex < len(types) {
+			cp.toggleTypeFilter(types[cp.typeIndex])
+		}
+	case "clear":
+		// Show all types again
+		cp.typeFilter = make(map[string]bool)
+	case "down":
+		if cp.typeIndex < len(types)-1 {
+			cp.typeIndex++
+		}
+	case "up":
+		if cp.typeIndex > 0 {
+			cp.typeIndex--
+		}
+	case "help":
+		// Show the keys of the type filter
+		cp.openHelp()
 	}
+	return cp, nil
+}
 
+// renderTypeFilterView renders the commit type filter interface
+func (cp *CherryPicker) renderTypeFilterView() string {
+	var s strings.Builder
+	
+	s.WriteString("🏷️  Filter by Commit Type\n")
+	s.WriteString("═══════════════════════════════════════════════════════════════════════════════\n\n")
+	s.WriteString(fmt.Sprintf("🌿 Cherry-picking from %s → %s\n", cp.config.Git.SourceBranch, cp.config.Git.TargetBranch))
+	s.WriteString(fmt.Sprintf("🏷️  Showing: %s\n\n", cp.typeFilterLabel()))
+	
+	types, counts := cp.getCommitTypes()
+	if len(types) == 0 {
+		s.WriteString("No commits loaded.\n\n")
+		s.WriteString(fmt.Sprintf("Controls: %s=go back, %s=quit\n", cp.keyFor(keyModeTypeFilter, "close"), cp.keyFor(keyModeTypeFilter, "quit")))
+		return s.String()
+	}
+	
+	for i, t := range types {
+		cursor := "  "
+		checkbox := "[ ]"
+		if cp.typeFilter[t] {
+			checkbox = "[✓]"
+		}
+		
+		label := fmt.Sprintf("%s (%d)", t, counts[t])
+		if t == otherCommitType {
+			label = fmt.Sprintf("%s (%d, not conventional)", t, counts[t])
+		}
+		if i == cp.typeIndex {
+			cursor = "→ "
+			label = "\033[7m" + label + "\033[0m"
+		}
+		s.WriteString(fmt.Sprintf("%s%s %s\n", cursor, checkbox, label))
+	}
+	
+	s.WriteString("\n")
+	if cp.isHotfixTarget() {
+		s.WriteString(fmt.Sprintf("💡 %s is a hotfix target; consider showing only fix and perf.\n\n", cp.config.Git.TargetBranch))
+	}
+	s.WriteString(cp.controlsLine(keyModeTypeFilter) + "\n")
+	
 	return s.String()
 }
 
@@ -905,6 +1254,8 @@ func (cp *CherryPicker) getStatusLine() string {
 	
 	if cp.searchMode {
 		status = append(status, "🔍 Search Mode")
+	} else if cp.searchActive() {
+		status = append(status, "🔍 Filtered")
 	}
 	
 	if cp.previewMode {
@@ -923,6 +1274,26 @@ func (cp *CherryPicker) getStatusLine() string {
 		status = append(status, "🔍 Detail View")
 	}
 	
+	if cp.groupByTicket {
+		status = append(status, "🎫 Grouped by ticket")
+	}
+	
+	if cp.typeFilterActive() {
+		status = append(status, "🏷️  Types: "+cp.typeFilterLabel())
+	}
+	
+	if len(cp.paths) > 0 {
+		status = append(status, "🗂️  Paths: "+cp.pathsLabel())
+	}
+	
+	if cp.pickaxeActive() {
+		status = append(status, "🔎 Diff: "+cp.pickaxeLabel())
+	}
+	
+	if cp.policyOverride {
+		status = append(status, "⛔ Policy override ON")
+	}
+	
 	if cp.conflictMode {
 		conflictCount := len(cp.conflictFiles)
 		if conflictCount > 0 {
@@ -968,48 +1339,17 @@ func (cp *CherryPicker) getStatusLine() string {
 	return "Status: " + strings.Join(status, " | ")
 }
 
-// getControlsDisplay returns help text for available controls
+// getControlsDisplay returns the controls line under the list: the main keys of the list or
+// of search mode; ? lists them all
 func (cp *CherryPicker) getControlsDisplay() string {
-	var controls []string
-	
 	if cp.searchMode {
-		// Search mode controls
-		controls = append(controls, "type=search")
-		controls = append(controls, "ESC=exit search")
-		controls = append(controls, "ENTER=keep filter")
-		controls = append(controls, "↑↓=navigate")
-		controls = append(controls, "TAB=toggle")
-		controls = append(controls, "BACKSPACE=delete")
-	} else {
-		// Normal mode controls
-		// Navigation & Selection
-		controls = append(controls, "↑↓/k j=navigate")
-		controls = append(controls, "PgUp/PgDn=page")
-		controls = append(controls, "ENTER/SPACE=toggle")
-		controls = append(controls, "r=range select")
-		controls = append(controls, "a=select all")
-		controls = append(controls, "c=clear all")
-		
-		// Search & View Options
-		controls = append(controls, "/f=SEARCH")
-		controls = append(controls, "p/TAB=PREVIEW")
-		controls = append(controls, "b=TARGET BRANCH")
-		controls = append(controls, "B=SOURCE BRANCH")
-		controls = append(controls, "A=AUTHOR")
-		controls = append(controls, "d=detail view")
-		controls = append(controls, "H=HIDE APPLIED")
-		controls = append(controls, "R=REVERSE ORDER")
-		
-		// Actions
-		controls = append(controls, "e/x=execute cherry-pick")
-		controls = append(controls, "i=interactive rebase")
-		controls = append(controls, "q=quit")
+		return "Controls: " + strings.Join(append([]string{"type=search"}, cp.controlItems(keyModeSearch)...), ", ")
 	}
-	
-	return "Controls: " + strings.Join(controls, ", ")
+	return cp.controlsLine(keyModeList)
 }
 
-func (cp *CherryPicker) getSelectedCommitsDisplay() string {
+// getSelectedCommitsDisplay lists the selected commits, at most limit of them (0 for all)
+func (cp *CherryPicker) getSelectedCommitsDisplay(limit int) string {
 	selectedCommits := cp.getSelectedCommits()
 
 	if len(selectedCommits) == 0 {
@@ -1018,7 +1358,11 @@ func (cp *CherryPicker) getSelectedCommitsDisplay() string {
 
 	var s strings.Builder
 	s.WriteString(fmt.Sprintf("Selected commits (%d):\n", len(selectedCommits)))
-	for _, commit := range selectedCommits {
+	for i, commit := range selectedCommits {
+		if limit > 0 && i >= limit {
+			s.WriteString(fmt.Sprintf("  ... and %d more\n", len(selectedCommits)-limit))
+			break
+		}
 		s.WriteString(fmt.Sprintf("  ✓ %s\n", commit.Full))
 	}
 
@@ -1027,29 +1371,32 @@ func (cp *CherryPicker) getSelectedCommitsDisplay() string {
 
 // handleEditorInput handles keyboard input when in editor selection mode
 func (cp *CherryPicker) handleEditorInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	switch cp.keyAction(keyModeEditor, msg) {
+	case "quit":
 		cp.quitting = true
 		return cp, tea.Batch(tea.ExitAltScreen, tea.Quit)
-	case "esc":
+	case "close":
 		// Exit editor mode, back to conflict mode
 		cp.exitEditorMode()
-	case "enter", " ":
+	case "choose":
 		// Select the current editor and open it
 		if err := cp.selectEditor(); err != nil {
 			// Handle error, but for now just exit editor mode
 			cp.exitEditorMode()
 		}
-	case "down", "j":
+	case "down":
 		// Navigate down in editor list
 		if cp.editorIndex < len(cp.availableEditors)-1 {
 			cp.editorIndex++
 		}
-	case "up", "k":
+	case "up":
 		// Navigate up in editor list
 		if cp.editorIndex > 0 {
 			cp.editorIndex--
 		}
+	case "help":
+		// Show the keys of editor selection
+		cp.openHelp()
 	}
 	return cp, nil
 }
@@ -1063,7 +1410,7 @@ func (cp *CherryPicker) renderEditorView() string {
 	
 	if len(cp.availableEditors) == 0 {
 		s.WriteString("❌ No editors found on your system.\n\n")
-		s.WriteString("Press ESC to go back to conflict resolution options.")
+		s.WriteString("Press " + cp.keyFor(keyModeEditor, "close") + " to go back to conflict resolution options.")
 		return s.String()
 	}
 	
@@ -1090,10 +1437,7 @@ func (cp *CherryPicker) renderEditorView() string {
 	}
 	
 	s.WriteString("Controls:\n")
-	s.WriteString("• ↑↓ or j/k = Navigate editors\n")
-	s.WriteString("• Enter/Space = Select editor\n")
-	s.WriteString("• ESC = Back to conflict options\n")
-	s.WriteString("• q = Quit\n")
+	s.WriteString(cp.keyOptions(keyModeEditor))
 	
 	return s.String()
 }
\ No newline at end of file
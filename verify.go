@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// verifyPick runs the configured verification command after a pick (or once at the end
+// when sha is empty). A failure enters verify mode and returns VERIFY_FAILED for main.go.
+func (cp *CherryPicker) verifyPick(sha string) error {
+	command := cp.config.Behavior.VerifyCommand
+	if command == "" {
+		return nil
+	}
+
+	target := "run"
+	if sha != "" {
+		target = sha
+		if len(target) > 8 {
+			target = target[:8]
+		}
+	}
+	fmt.Printf("🧪 Verifying %s: %s\n", target, command)
+
+	output, err := shellCommand(command).CombinedOutput()
+	if err == nil {
+		fmt.Println("✅ Verification passed.")
+		return nil
+	}
+
+	fmt.Printf("❌ Verification failed: %v\n", err)
+	cp.enterVerifyMode(sha, string(output))
+	return fmt.Errorf("VERIFY_FAILED:%s", sha)
+}
+
+// enterVerifyMode sets up the failed-verification review state
+func (cp *CherryPicker) enterVerifyMode(sha, output string) {
+	cp.verifyMode = true
+	cp.verifyCommit = sha
+	cp.verifyOutput = output
+	cp.verifyDecision = ""
+}
+
+// exitVerifyMode clears the failed-verification review state
+func (cp *CherryPicker) exitVerifyMode() {
+	cp.verifyMode = false
+	cp.verifyCommit = ""
+	cp.verifyOutput = ""
+}
+
+// applyVerifyDecision acts on the choice made in verify mode and resumes the run if asked to
+func (cp *CherryPicker) applyVerifyDecision() error {
+	decision := cp.verifyDecision
+	sha := cp.verifyCommit
+	cp.exitVerifyMode()
+	cp.verifyDecision = ""
+
+	switch decision {
+	case "revert":
+		if sha == "" {
+			// Verification ran once at the end: drop every pick of this run
+			if err := cp.resetRun(); err != nil {
+				return err
+			}
+			cp.reportRun()
+			return fmt.Errorf("reverted all picks after failed verification")
+		}
+		base := cp.pickBases[sha]
+		if base == "" {
+			return fmt.Errorf("don't know where %s was picked onto; cannot revert it", sha)
+		}
+		fmt.Printf("↩️  Reverting %s...\n", sha)
+		if err := exec.Command("git", "reset", "--hard", base).Run(); err != nil {
+			return fmt.Errorf("failed to revert %s: %v", sha, err)
+		}
+		cp.dropPicked(sha)
+		cp.removeBackportNote(sha, cp.pickedTargets[sha])
+		delete(cp.pickedTargets, sha)
+		cp.ledgerRecord(LedgerEntry{SourceSHA: sha, Resolution: "reverted after failed verification"})
+		return cp.pickCommits()
+	case "continue":
+		return cp.pickCommits()
+	case "abort":
+		if err := cp.resetRun(); err != nil {
+			return err
+		}
+		cp.reportRun()
+		return fmt.Errorf("run aborted after failed verification")
+	default:
+		cp.reportRun()
+		return fmt.Errorf("verification failed; run stopped with picks left in place")
+	}
+}
+
+// resetRun moves the target branch back to where the run started
+func (cp *CherryPicker) resetRun() error {
+	if cp.runStartHead == "" {
+		return fmt.Errorf("run start is unknown; cannot roll back")
+	}
+	fmt.Printf("↩️  Resetting %s to %s...\n", cp.pickBranch(), cp.runStartHead[:8])
+	if err := exec.Command("git", "reset", "--hard", cp.runStartHead).Run(); err != nil {
+		return fmt.Errorf("failed to reset %s: %v", cp.pickBranch(), err)
+	}
+	for _, sha := range cp.pickedSHAs {
+		cp.ledgerRecord(LedgerEntry{SourceSHA: sha, Resolution: "rolled back with the run"})
+	}
+	for sha, targetSHA := range cp.pickedTargets {
+		cp.removeBackportNote(sha, targetSHA)
+	}
+	cp.pickedSHAs = nil
+	cp.pickedTargets = make(map[string]string)
+	return nil
+}
+
+// dropPicked removes a SHA from the list of commits picked in this run
+func (cp *CherryPicker) dropPicked(sha string) {
+	var kept []string
+	for _, picked := range cp.pickedSHAs {
+		if picked != sha {
+			kept = append(kept, picked)
+		}
+	}
+	cp.pickedSHAs = kept
+}
+
+// verifyOutputTail returns the last lines of the verification output
+func (cp *CherryPicker) verifyOutputTail(maxLines int) []string {
+	lines := strings.Split(strings.TrimRight(cp.verifyOutput, "\n"), "\n")
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return lines
+}
@@ -0,0 +1,191 @@
+package main
+
+import "sort"
+
+// The commit list is a single projection of cp.commits: the search, applied, type and diff
+// search filters drop commits, a ranked search reorders them and ticket grouping regroups
+// them. cp.currentIndex is always a position in this projection. Everything that has to
+// survive re-projection (selection, search results, the range anchor) refers to commits by SHA.
+
+// searchActive reports whether the list is limited to search results
+func (cp *CherryPicker) searchActive() bool {
+	return cp.searchMatches != nil
+}
+
+// getVisibleCommits returns the commits as displayed, in display order
+func (cp *CherryPicker) getVisibleCommits() []Commit {
+	var visible []Commit
+	for _, commit := range cp.commits {
+		if cp.searchActive() {
+			if _, ok := cp.searchMatches[commit.SHA]; !ok {
+				continue
+			}
+		}
+		if cp.hideApplied && commit.AlreadyApplied {
+			continue
+		}
+		visible = append(visible, commit)
+	}
+
+	// Best fuzzy matches first; ties keep the list order
+	if cp.searchActive() && cp.searchFilter != nil && cp.searchFilter.Ranked() {
+		sort.SliceStable(visible, func(a, b int) bool {
+			return cp.searchMatches[visible[a].SHA] > cp.searchMatches[visible[b].SHA]
+		})
+	}
+
+	// Keep only the commit types in the type filter
+	if cp.typeFilterActive() {
+		visible = cp.filterCommitsByType(visible)
+	}
+
+	// Keep only the commits found by the diff search
+	if cp.pickaxeActive() {
+		visible = cp.filterCommitsByPickaxe(visible)
+	}
+
+	// Order by ticket and drop collapsed rows when grouping is enabled
+	if cp.groupByTicket {
+		return cp.groupCommitsByTicket(visible)
+	}
+	return visible
+}
+
+// visibleIndex returns the position of a commit in the visible list, or -1 if it's hidden
+func (cp *CherryPicker) visibleIndex(sha string) int {
+	return commitIndex(cp.getVisibleCommits(), sha)
+}
+
+// commitIndex returns the position of a commit in an already built projection, or -1
+func commitIndex(commits []Commit, sha string) int {
+	if sha == "" {
+		return -1
+	}
+	for i, commit := range commits {
+		if commit.SHA == sha {
+			return i
+		}
+	}
+	return -1
+}
+
+// findCommit returns the loaded commit with the given SHA, or nil
+func (cp *CherryPicker) findCommit(sha string) *Commit {
+	for i := range cp.commits {
+		if cp.commits[i].SHA == sha {
+			return &cp.commits[i]
+		}
+	}
+	return nil
+}
+
+// getCurrentCommit returns the commit under the cursor
+func (cp *CherryPicker) getCurrentCommit() *Commit {
+	visible := cp.getVisibleCommits()
+	if cp.currentIndex < 0 || cp.currentIndex >= len(visible) {
+		return nil
+	}
+	return cp.findCommit(visible[cp.currentIndex].SHA)
+}
+
+// getMaxIndex returns the maximum valid cursor position
+func (cp *CherryPicker) getMaxIndex() int {
+	return len(cp.getVisibleCommits()) - 1
+}
+
+// focusCommit moves the cursor to a commit, or keeps it in bounds if the commit is hidden
+func (cp *CherryPicker) focusCommit(sha string) {
+	if i := cp.visibleIndex(sha); i >= 0 {
+		cp.currentIndex = i
+		return
+	}
+	if maxIndex := cp.getMaxIndex(); cp.currentIndex > maxIndex {
+		cp.currentIndex = maxIndex
+	}
+	if cp.currentIndex < 0 {
+		cp.currentIndex = 0
+	}
+}
+
+// moveCursor moves the cursor by delta visible commits, stopping at either end
+func (cp *CherryPicker) moveCursor(delta int) {
+	maxIndex := cp.getMaxIndex()
+	if maxIndex < 0 {
+		return
+	}
+	cp.currentIndex = min(max(cp.currentIndex+delta, 0), maxIndex)
+	cp.updatePreview()
+}
+
+// toggleCurrentCommit selects or deselects the commit under the cursor, unless it's applied
+func (cp *CherryPicker) toggleCurrentCommit() {
+	commit := cp.getCurrentCommit()
+	if commit != nil && !commit.AlreadyApplied {
+		cp.selected[commit.SHA] = !cp.selected[commit.SHA]
+	}
+}
+
+// keepCursor applies a change to the view, keeping the cursor on the same commit
+func (cp *CherryPicker) keepCursor(change func()) {
+	sha := ""
+	if commit := cp.getCurrentCommit(); commit != nil {
+		sha = commit.SHA
+	}
+	change()
+	cp.focusCommit(sha)
+}
+
+// toggleRangeSelection starts a range at the current commit, or selects the range and ends it
+func (cp *CherryPicker) toggleRangeSelection() {
+	if !cp.rangeSelection {
+		cp.rangeSelection = true
+		cp.rangeAnchor = ""
+		if commit := cp.getCurrentCommit(); commit != nil {
+			cp.rangeAnchor = commit.SHA
+		}
+	} else {
+		cp.selectRange()
+		cp.rangeSelection = false
+		cp.rangeAnchor = ""
+	}
+}
+
+// rangeBounds returns the visible positions between the range anchor and the cursor. If the
+// anchor has been filtered out the range shrinks to the cursor.
+func (cp *CherryPicker) rangeBounds() (int, int) {
+	return cp.rangeBoundsIn(cp.getVisibleCommits())
+}
+
+// rangeBoundsIn is rangeBounds over an already built projection, so callers that render
+// many rows project the list only once
+func (cp *CherryPicker) rangeBoundsIn(visible []Commit) (int, int) {
+	start := commitIndex(visible, cp.rangeAnchor)
+	if start < 0 {
+		start = cp.currentIndex
+	}
+	end := cp.currentIndex
+	if start > end {
+		start, end = end, start
+	}
+	return start, end
+}
+
+// selectRange selects the visible commits in the current range (except already applied ones)
+func (cp *CherryPicker) selectRange() {
+	visible := cp.getVisibleCommits()
+	start, end := cp.rangeBoundsIn(visible)
+	for i := start; i <= end && i < len(visible); i++ {
+		if !visible[i].AlreadyApplied {
+			cp.selected[visible[i].SHA] = true
+		}
+	}
+}
+
+// rangeRows returns the positions of visible covered by the range selection, or an empty
+// span when no range is being selected
+func (cp *CherryPicker) rangeRows(visible []Commit) (int, int) {
+	if !cp.rangeSelection {
+		return 0, -1
+	}
+	return cp.rangeBoundsIn(visible)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testCommits returns commits a..f: b is already applied, c and e share a ticket, a and d are fixes
+func testCommits() []Commit {
+	fix := &ConventionalCommit{Type: "fix"}
+	return []Commit{
+		{SHA: "a", Message: "fix: a", Conventional: fix},
+		{SHA: "b", Message: "b", AlreadyApplied: true},
+		{SHA: "c", Message: "c", Tickets: []string{"ABC-1"}},
+		{SHA: "d", Message: "fix: d", Conventional: fix},
+		{SHA: "e", Message: "e", Tickets: []string{"ABC-1"}},
+		{SHA: "f", Message: "f"},
+	}
+}
+
+// newViewPicker returns a picker over testCommits with changes applied
+func newViewPicker(change func(cp *CherryPicker)) *CherryPicker {
+	cp := &CherryPicker{config: DefaultConfig(), commits: testCommits(), selected: map[string]bool{}}
+	if change != nil {
+		change(cp)
+	}
+	return cp
+}
+
+// shas returns the SHAs of commits joined into a string
+func shas(commits []Commit) string {
+	var s strings.Builder
+	for _, commit := range commits {
+		s.WriteString(commit.SHA)
+	}
+	return s.String()
+}
+
+func TestGetVisibleCommits(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cp *CherryPicker)
+		want   string
+	}{
+		{"everything", nil, "abcdef"},
+		{"hide applied", func(cp *CherryPicker) { cp.hideApplied = true }, "acdef"},
+		{"search", func(cp *CherryPicker) {
+			cp.searchMatches = map[string]int{"f": 1, "c": 3, "a": 2}
+		}, "acf"},
+		{"ranked search", func(cp *CherryPicker) {
+			cp.searchFilter, _ = parseCommitQuery("x")
+			cp.searchMatches = map[string]int{"f": 1, "c": 3, "a": 2, "e": 3}
+		}, "ceaf"},
+		{"type filter", func(cp *CherryPicker) { cp.typeFilter = map[string]bool{"fix": true} }, "ad"},
+		{"diff search", func(cp *CherryPicker) {
+			cp.pickaxeTerm = "x"
+			cp.pickaxeMatches = map[string]pickaxeChange{"b": {}, "e": {}}
+		}, "be"},
+		{"grouped by ticket", func(cp *CherryPicker) { cp.groupByTicket = true }, "ceabdf"},
+		{"collapsed ticket", func(cp *CherryPicker) {
+			cp.groupByTicket = true
+			cp.collapsedTickets = map[string]bool{"ABC-1": true}
+		}, "cabdf"},
+		{"filters combine", func(cp *CherryPicker) {
+			cp.hideApplied = true
+			cp.groupByTicket = true
+			cp.searchMatches = map[string]int{"a": 1, "b": 1, "e": 1, "f": 1}
+		}, "eaf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := newViewPicker(tt.change)
+			if got := shas(cp.getVisibleCommits()); got != tt.want {
+				t.Errorf("visible = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRangeBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		change    func(cp *CherryPicker)
+		anchor    string
+		cursor    int
+		wantStart int
+		wantEnd   int
+	}{
+		{"anchor above cursor", nil, "b", 4, 1, 4},
+		{"anchor below cursor", nil, "e", 1, 1, 4},
+		{"anchor at cursor", nil, "c", 2, 2, 2},
+		{"anchor filtered out", func(cp *CherryPicker) { cp.hideApplied = true }, "b", 3, 3, 3},
+		{"anchor moved by grouping", func(cp *CherryPicker) { cp.groupByTicket = true }, "e", 5, 1, 5},
+		{"no anchor", nil, "", 2, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := newViewPicker(tt.change)
+			cp.rangeAnchor = tt.anchor
+			cp.currentIndex = tt.cursor
+			start, end := cp.rangeBounds()
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("rangeBounds = %d-%d, want %d-%d", start, end, tt.wantStart, tt.wantEnd)
+			}
+			if start, end := cp.rangeBoundsIn(cp.getVisibleCommits()); start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("rangeBoundsIn = %d-%d, want %d-%d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestRangeRowsWithoutRangeSelection(t *testing.T) {
+	cp := newViewPicker(nil)
+	cp.rangeAnchor = "a"
+	cp.currentIndex = 3
+	if start, end := cp.rangeRows(cp.getVisibleCommits()); start <= end {
+		t.Errorf("rangeRows = %d-%d, want an empty span", start, end)
+	}
+	cp.rangeSelection = true
+	if start, end := cp.rangeRows(cp.getVisibleCommits()); start != 0 || end != 3 {
+		t.Errorf("rangeRows = %d-%d, want 0-3", start, end)
+	}
+}
+
+func TestSelectRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cp *CherryPicker)
+		anchor string
+		cursor int
+		want   []string
+	}{
+		{"skips applied commits", nil, "a", 2, []string{"a", "c"}},
+		{"selects in display order", func(cp *CherryPicker) { cp.groupByTicket = true }, "c", 2, []string{"a", "c", "e"}},
+		{"only visible commits", func(cp *CherryPicker) { cp.typeFilter = map[string]bool{"fix": true} }, "a", 1, []string{"a", "d"}},
+		{"hidden anchor selects the cursor", func(cp *CherryPicker) { cp.hideApplied = true }, "b", 2, []string{"d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := newViewPicker(tt.change)
+			cp.rangeAnchor = tt.anchor
+			cp.currentIndex = tt.cursor
+			cp.selectRange()
+
+			var got []string
+			for _, commit := range cp.commits {
+				if cp.selected[commit.SHA] {
+					got = append(got, commit.SHA)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selected = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeepCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor int
+		change func(cp *CherryPicker)
+		want   int
+	}{
+		{"follows the commit when regrouped", 4, func(cp *CherryPicker) { cp.groupByTicket = true }, 1},
+		{"follows the commit when filtered", 3, func(cp *CherryPicker) { cp.hideApplied = true }, 2},
+		{"stays in place when the commit is hidden", 1, func(cp *CherryPicker) { cp.hideApplied = true }, 1},
+		{"clamps when the list shrinks", 5, func(cp *CherryPicker) {
+			cp.pickaxeTerm = "x"
+			cp.pickaxeMatches = map[string]pickaxeChange{"a": {}, "b": {}}
+		}, 1},
+		{"clamps to zero on an empty list", 3, func(cp *CherryPicker) { cp.searchMatches = map[string]int{} }, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := newViewPicker(nil)
+			cp.currentIndex = tt.cursor
+			cp.keepCursor(func() { tt.change(cp) })
+			if cp.currentIndex != tt.want {
+				t.Errorf("currentIndex = %d, want %d", cp.currentIndex, tt.want)
+			}
+		})
+	}
+}